@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var flagUsageDays int
+
+var aiCmd = &cobra.Command{
+	Use:   "ai",
+	Short: "Inspect AI provider usage",
+}
+
+var aiUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show AI token usage and estimated spend by day",
+	Long: `List the AI requests made each day, by purpose, with their token usage and
+estimated cost.
+
+Costs come from the built-in price table for the default models and from
+ai.prices in the config. Models without a price show "unpriced" and do not
+count toward ai.daily_cap. When ai.daily_cap or ai.daily_token_cap is set,
+AI requests stop for the rest of the day once today's usage reaches it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		now := time.Now()
+		rows, err := db.AIUsageSince(now.AddDate(0, 0, -(max(flagUsageDays, 1) - 1)))
+		if err != nil {
+			return fmt.Errorf("reading AI usage: %w", err)
+		}
+		today, err := db.AIUsageOn(now)
+		if err != nil {
+			return fmt.Errorf("reading AI usage: %w", err)
+		}
+
+		var unpriced int // today's tokens on models without a price
+		if len(rows) == 0 {
+			fmt.Println("No AI requests recorded.")
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "DAY\tPURPOSE\tMODEL\tCALLS\tINPUT\tOUTPUT\tCOST")
+			for _, r := range rows {
+				cost := formatCost(r.Cost)
+				if !priced(cfg.AI, r.Model) {
+					cost = "unpriced"
+					if r.Day == today.Day {
+						unpriced += r.Tokens()
+					}
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+					r.Day, r.Purpose, r.Model, r.Calls, r.InputTokens, r.OutputTokens, cost)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		fmt.Println()
+		fmt.Println(capStatus(cfg.AI, today))
+		if unpriced > 0 {
+			fmt.Printf("Warning: %d tokens today were on models with no price in ai.prices; their cost is unknown and not counted toward daily_cap.\n", unpriced)
+		}
+		return nil
+	},
+}
+
+func init() {
+	aiUsageCmd.Flags().IntVar(&flagUsageDays, "days", 7, "number of days to show, including today")
+	aiCmd.AddCommand(aiUsageCmd)
+}
+
+// priced reports whether model has a known price.
+func priced(a *config.AIConfig, model string) bool {
+	if a == nil {
+		a = &config.AIConfig{}
+	}
+	_, ok := a.PriceOf(model)
+	return ok
+}
+
+// capStatus summarizes today's usage against the configured daily caps.
+func capStatus(a *config.AIConfig, today cache.AIUsageTotal) string {
+	var caps []string
+	if a != nil && a.DailyCap > 0 {
+		caps = append(caps, fmt.Sprintf("%s of %s", formatCost(today.Cost), formatCost(a.DailyCap)))
+	}
+	if a != nil && a.DailyTokenCap > 0 {
+		caps = append(caps, fmt.Sprintf("%d of %d tokens", today.Tokens(), a.DailyTokenCap))
+	}
+	if len(caps) == 0 {
+		return fmt.Sprintf("Today: %s, %d tokens (no daily cap).", formatCost(today.Cost), today.Tokens())
+	}
+	return "Today: " + strings.Join(caps, ", ") + "."
+}
+
+func formatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return fmt.Sprintf("$%.4f", usd)
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
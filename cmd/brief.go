@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/ai"
+	"github.com/matheuskafuri/devnews/internal/briefing"
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagBriefFormat string
+	flagBriefFocus  string
+	flagBriefSince  string
+	flagBriefWait   time.Duration
+)
+
+var briefCmd = &cobra.Command{
+	Use:   "brief",
+	Short: "Print the briefing to stdout",
+	Long: `Print today's briefing without opening the TUI, as plain text, ANSI-colored
+text, Markdown or JSON — for shell startup files, MOTD or posting to chat.
+
+The briefing is built from the cache; run "devnews refresh" first (or from
+cron) to include the latest posts. The briefing size comes from brief_size in
+the config.
+
+When AI is configured, --wait gives the AI that long to write the "why it
+matters" lines and themes. Whatever is ready when the time is up is used; the
+rest falls back to the feed description and keyword themes.`,
+	Example: `  devnews brief
+  devnews brief --format ansi --since 12h
+  devnews brief --format markdown --focus db:2,security,!ai --wait 20s
+  devnews brief --format json | jq '.cards[].title'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := briefing.ParseFormat(flagBriefFormat)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		focus, err := resolveFocus(cfg, flagBriefFocus)
+		if err != nil {
+			return err
+		}
+		var since time.Time
+		if flagBriefSince != "" {
+			d, err := parseSince(flagBriefSince)
+			if err != nil {
+				return fmt.Errorf("invalid --since value: %w", err)
+			}
+			since = time.Now().Add(-d)
+		}
+
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		var summarizer ai.Summarizer
+		if cfg.AIEnabled() {
+			if summarizer, err = ai.New(cfg.AI, cfg.AIKey(), db); err != nil {
+				// Stdout carries the briefing, which may be piped.
+				fmt.Fprintf(os.Stderr, "[warn] AI: %v\n", err)
+			}
+		}
+
+		b, err := generateBriefing(cfg, db, focus, since)
+		if err != nil {
+			return fmt.Errorf("generating briefing: %w", err)
+		}
+
+		if flagBriefWait > 0 && summarizer != nil {
+			ctx, cancel := context.WithTimeout(cmd.Context(), flagBriefWait)
+			briefing.Enrich(ctx, b, summarizer, db)
+			cancel()
+		}
+
+		return briefing.Render(os.Stdout, b, format)
+	},
+}
+
+func init() {
+	briefCmd.Flags().StringVarP(&flagBriefFormat, "format", "f", "text", "output format (text, ansi, markdown, json)")
+	briefCmd.Flags().StringVar(&flagBriefFocus, "focus", "", focusFlagUsage)
+	briefCmd.Flags().StringVar(&flagBriefSince, "since", "", "include articles from the last duration (default 24h)")
+	briefCmd.Flags().DurationVar(&flagBriefWait, "wait", 0, "wait up to this long for AI enrichment (e.g., 20s)")
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/classify"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/matheuskafuri/devnews/internal/export"
+	"github.com/matheuskafuri/devnews/internal/opml"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagExportFormat   string
+	flagExportSince    string
+	flagExportSources  []string
+	flagExportCategory string
+	flagExportOutput   string
+	flagExportLimit    int
+	flagExportOPML     bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export cached articles as Markdown, JSON or CSV, or sources as OPML",
+	Long: `Write cached articles, including AI summaries, to stdout or a file.
+
+Markdown produces a digest ready to paste into a newsletter; JSON and CSV
+include every field. With --opml, the enabled sources are exported instead,
+for importing into another feed reader.`,
+	Example: `  devnews export --since 7d > digest.md
+  devnews export --format json --source stripe --category security
+  devnews export --opml -o devnews.opml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagExportOPML {
+			cfg, err := loadConfig()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			return writeOutput(func(out io.Writer) error {
+				return opml.Write(out, "devnews sources", sourceFeeds(cfg.EnabledSources()))
+			})
+		}
+
+		format, err := export.ParseFormat(flagExportFormat)
+		if err != nil {
+			return err
+		}
+
+		opts := cache.QueryOpts{
+			SourceNames: flagExportSources,
+			Limit:       flagExportLimit,
+			OrderByDate: true,
+		}
+		if flagExportSince != "" {
+			d, err := parseSince(flagExportSince)
+			if err != nil {
+				return fmt.Errorf("invalid --since value: %w", err)
+			}
+			opts.Since = time.Now().Add(-d)
+		}
+		// Custom categories apply to the labels and the filter.
+		if _, err := loadConfig(); err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		if flagExportCategory != "" {
+			category, err := classify.ResolveAlias(flagExportCategory)
+			if err != nil {
+				return err
+			}
+			opts.Category = string(category)
+		}
+
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		if err := labelUnclassified(db, opts); err != nil {
+			return err
+		}
+		articles, err := db.GetArticles(opts)
+		if err != nil {
+			return err
+		}
+
+		return writeOutput(func(out io.Writer) error {
+			return export.Write(out, format, articles)
+		})
+	},
+}
+
+// writeOutput calls write with stdout, or with the --output file, which is
+// only created once everything else has succeeded.
+func writeOutput(write func(io.Writer) error) error {
+	if flagExportOutput == "" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(flagExportOutput)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// labelUnclassified classifies by keyword the articles matching opts that
+// have not been classified yet and saves their labels, so that the export
+// shows their categories and the category filter, which runs in the query
+// before the limit, sees them.
+func labelUnclassified(db *cache.Cache, opts cache.QueryOpts) error {
+	opts.Category = ""
+	opts.Limit = 0
+	opts.Unclassified = true
+	articles, err := db.GetArticles(opts)
+	if err != nil {
+		return err
+	}
+	for _, a := range articles {
+		labels := classify.ClassifyAll(a.Title, a.Description)
+		out := make([]cache.Label, len(labels))
+		for i, l := range labels {
+			out[i] = cache.Label{Category: string(l.Category), Score: l.Score}
+		}
+		if err := db.SetArticleLabels(a.ID, out, cache.CategorySourceKeyword); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sourceFeeds(sources []config.Source) []opml.Feed {
+	feeds := make([]opml.Feed, len(sources))
+	for i, s := range sources {
+		feeds[i] = opml.Feed{Title: s.Name, URL: s.URL, Type: s.Type}
+	}
+	return feeds
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&flagExportFormat, "format", "f", "markdown", "output format (markdown, json, csv)")
+	exportCmd.Flags().StringVar(&flagExportSince, "since", "", "only export articles from the last duration (e.g., 7d, 24h)")
+	exportCmd.Flags().StringSliceVar(&flagExportSources, "source", nil, "only export sources whose name contains this (repeatable)")
+	exportCmd.Flags().StringVar(&flagExportCategory, "category", "", "only export a category or alias, including custom ones (e.g. security, db)")
+	exportCmd.Flags().StringVarP(&flagExportOutput, "output", "o", "", "write to a file instead of stdout")
+	exportCmd.Flags().IntVar(&flagExportLimit, "limit", 1000, "maximum number of articles to export")
+	exportCmd.Flags().BoolVar(&flagExportOPML, "opml", false, "export enabled sources as OPML instead of articles")
+}
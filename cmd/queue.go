@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/matheuskafuri/devnews/internal/browser"
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var flagQueueAll bool
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "List the Read Later queue",
+	Long: `Show the articles waiting in the Read Later queue, in reading order.
+
+Articles are added with "devnews queue add" or by pressing a in the browser,
+and reordered from the Read Later screen. Queued articles are never pruned
+until they have been read.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		items, err := db.Queue(flagQueueAll, 0)
+		if err != nil {
+			return fmt.Errorf("reading queue: %w", err)
+		}
+		if len(items) == 0 {
+			fmt.Println("Read Later queue is empty.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "#\tID\tSOURCE\tTITLE\tADDED\tREAD")
+		n := 0
+		for _, q := range items {
+			pos := "-"
+			read := "-"
+			if q.Finished() {
+				read = formatAgo(q.FinishedAt)
+			} else {
+				n++
+				pos = fmt.Sprintf("%d", n)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				pos,
+				shortID(q.Article.ID),
+				q.Article.Source,
+				truncateError(q.Article.Title, 60),
+				formatAgo(q.AddedAt),
+				read,
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var queueAddCmd = &cobra.Command{
+	Use:   "add <url|id>...",
+	Short: "Add articles to the Read Later queue",
+	Long: `Add cached articles to the end of the Read Later queue.
+
+Each argument is either the article's link or a prefix of its ID as shown by
+"devnews queue".`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		for _, ref := range args {
+			a, err := db.FindArticle(ref)
+			if err != nil {
+				return err
+			}
+			if err := db.Enqueue(a.ID); err != nil {
+				return err
+			}
+			fmt.Printf("Queued: %s (%s)\n", a.Title, a.Source)
+		}
+		return nil
+	},
+}
+
+var queueNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Open the next article in the queue and mark it read",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		item, ok, err := db.NextQueued()
+		if err != nil {
+			return fmt.Errorf("reading queue: %w", err)
+		}
+		if !ok {
+			fmt.Println("Read Later queue is empty.")
+			return nil
+		}
+
+		fmt.Printf("%s (%s)\n%s\n", item.Article.Title, item.Article.Source, item.Article.Link)
+		if err := browser.Open(item.Article.Link); err != nil {
+			return fmt.Errorf("opening browser: %w", err)
+		}
+		if err := db.MarkArticleRead(item.Article.ID); err != nil {
+			return err
+		}
+		return db.FinishQueued(item.Article.ID)
+	},
+}
+
+// shortID returns the leading characters of an article ID, enough to pass
+// back to "queue add".
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func init() {
+	queueCmd.Flags().BoolVar(&flagQueueAll, "all", false, "include articles already read")
+	queueCmd.AddCommand(queueAddCmd)
+	queueCmd.AddCommand(queueNextCmd)
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/ai"
+	"github.com/matheuskafuri/devnews/internal/briefing"
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/matheuskafuri/devnews/internal/refresh"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRefreshBackfill string
+	flagRefreshWatch    bool
+)
+
+var refreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Fetch all enabled sources into the cache",
+	Long: `Fetch every enabled source, store new articles and prune old ones, then
+print a summary. The exit code is non-zero when any source fails, so the
+command can be run from cron or a systemd timer to keep the cache warm.
+
+With --watch, devnews keeps running and refreshes every refresh_interval,
+shifted by up to 10% at random. The config is re-read before each refresh.
+
+Feed items older than the backfill window (the "backfill" setting, default 7d)
+are skipped. --backfill does a one-time historical import: every feed is
+downloaded in full, ignoring cached ETags, and items within the given window
+are kept. Articles older than the retention setting are pruned on the next
+regular refresh, so raise retention too if you want to keep them.
+
+When ai.classify is enabled, new articles are categorized by the AI after
+each refresh, and when ai.prefetch is enabled, the newest unread articles are
+summarized.`,
+	Example: `  devnews refresh
+  devnews refresh --watch
+  devnews refresh --backfill 90d`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts refresh.Options
+		if flagRefreshBackfill != "" {
+			if flagRefreshWatch {
+				return fmt.Errorf("--backfill and --watch cannot be combined")
+			}
+			window, err := parseSince(flagRefreshBackfill)
+			if err != nil {
+				return fmt.Errorf("invalid --backfill value: %w", err)
+			}
+			opts.Backfill = window
+		}
+
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if flagRefreshWatch {
+			return watchRefresh(ctx, db)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		summary, err := runRefresh(ctx, cfg, db, opts, "")
+		if err != nil {
+			return err
+		}
+		if summary.Failed() {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d source(s) failed", len(summary.Errors), summary.Sources)
+		}
+		return nil
+	},
+}
+
+// runRefresh runs one refresh and prints its warnings and summary, with the
+// summary line prefixed by prefix.
+func runRefresh(ctx context.Context, cfg *config.Config, db *cache.Cache, opts refresh.Options, prefix string) (refresh.Summary, error) {
+	summary, err := refresh.Run(ctx, cfg, db, opts)
+	for _, e := range summary.Errors {
+		fmt.Printf("  [warn] %v\n", e)
+	}
+	if err != nil {
+		return summary, err
+	}
+	fmt.Println(prefix + summary.String())
+	if cfg.AIEnabled() {
+		s, err := ai.New(cfg.AI, cfg.AIKey(), db)
+		if err != nil {
+			fmt.Printf("%s[warn] AI: %v\n", prefix, err)
+			return summary, nil
+		}
+		classifyArticles(ctx, cfg, db, s, prefix)
+		prefetch(ctx, cfg, db, s, prefix)
+	}
+	return summary, nil
+}
+
+// classifyArticles assigns AI categories to new articles when ai.classify
+// is on. Failures are reported but never fail the refresh.
+func classifyArticles(ctx context.Context, cfg *config.Config, db *cache.Cache, s ai.Summarizer, prefix string) {
+	c := aiClassifier(cfg, s)
+	if c == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, briefing.ClassifyTimeout)
+	defer cancel()
+	if err := briefing.Classify(ctx, db, c); err != nil {
+		fmt.Printf("%s[warn] classify: %v\n", prefix, err)
+	}
+}
+
+// prefetch summarizes the newest unread articles when ai.prefetch is on, so
+// the TUI and briefing show AI text right away. Failures are reported but
+// never fail the refresh.
+func prefetch(ctx context.Context, cfg *config.Config, db *cache.Cache, s ai.Summarizer, prefix string) {
+	p := cfg.GetPrefetch()
+	if !p.Enabled {
+		return
+	}
+	jobs, err := ai.PrefetchJobs(db, cfg.SourceNames(), p.Count, p.Budget)
+	if err != nil {
+		fmt.Printf("%s[warn] prefetch: %v\n", prefix, err)
+		return
+	}
+	if len(jobs) == 0 {
+		return
+	}
+	failed := ai.RunJobs(ctx, s, db, jobs, nil)
+	fmt.Printf("%sPrefetched %d AI request(s), %d failed.\n", prefix, len(jobs)-failed, failed)
+}
+
+// watchRefresh refreshes on the configured interval until ctx is cancelled.
+// Failed sources and cache errors are reported but do not stop the loop, and
+// a config that stops loading is reported while the last good one stays in use.
+func watchRefresh(ctx context.Context, db *cache.Cache) error {
+	var cfg *config.Config
+	for {
+		stamp := "[" + time.Now().Format("2006-01-02 15:04:05") + "] "
+		next, err := loadConfig()
+		switch {
+		case err == nil:
+			cfg = next
+		case cfg == nil:
+			return fmt.Errorf("loading config: %w", err)
+		default:
+			fmt.Printf("%s[error] loading config, keeping the previous one: %v\n", stamp, err)
+		}
+		if _, err := runRefresh(ctx, cfg, db, refresh.Options{}, stamp); err != nil {
+			fmt.Printf("%s[error] %v\n", stamp, err)
+		}
+
+		wait := refresh.Jitter(cfg.RefreshDuration())
+		fmt.Printf("Next refresh at %s.\n", time.Now().Add(wait).Format("15:04:05"))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func init() {
+	refreshCmd.Flags().StringVar(&flagRefreshBackfill, "backfill", "", "import items up to this old, bypassing cached ETags (e.g., 90d)")
+	refreshCmd.Flags().BoolVar(&flagRefreshWatch, "watch", false, "keep running and refresh every refresh_interval")
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/matheuskafuri/devnews/internal/classify"
+	"github.com/matheuskafuri/devnews/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +22,8 @@ var (
 	flagFocus   string
 )
 
+const focusFlagUsage = "rank briefing categories higher, e.g. db:2,security,!ai to weight db double and leave out ai (any category or alias, including custom ones)"
+
 var rootCmd = &cobra.Command{
 	Use:   "devnews",
 	Short: "TUI engineering blog aggregator",
@@ -27,16 +31,40 @@ var rootCmd = &cobra.Command{
 	RunE:  runTUI,
 }
 
+// loadConfig loads the config file and applies its category definitions.
+// Every command loads the config through it, so that custom categories are
+// used wherever articles are classified.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load(flagConfig)
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]classify.Definition, len(cfg.Categories))
+	for i, c := range cfg.Categories {
+		defs[i] = classify.Definition{Name: c.Name, Aliases: c.Aliases, Keywords: c.Keywords, Replace: c.Replace}
+	}
+	if err := classify.Configure(defs); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func init() {
 	rootCmd.Flags().StringVar(&flagSince, "since", "", "only show articles from the last duration (e.g., 7d, 24h)")
 	rootCmd.Flags().BoolVar(&flagRefresh, "refresh", false, "force refresh feeds before launching")
-	rootCmd.Flags().StringVar(&flagConfig, "config", "", "path to config file")
-	rootCmd.Flags().StringVar(&flagFocus, "focus", "", "filter briefing to category (infra, ai, db, distributed, security, tools, platform)")
+	rootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "path to config file")
+	rootCmd.Flags().StringVar(&flagFocus, "focus", "", focusFlagUsage)
 
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(pruneCmd)
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(sourcesCmd)
+	rootCmd.AddCommand(queueCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(refreshCmd)
+	rootCmd.AddCommand(briefCmd)
+	rootCmd.AddCommand(aiCmd)
 }
 
 var versionCmd = &cobra.Command{
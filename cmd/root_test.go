@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/classify"
+	"github.com/matheuskafuri/devnews/internal/config"
 )
 
 func TestParseSince(t *testing.T) {
@@ -38,3 +45,127 @@ func TestParseSince(t *testing.T) {
 		}
 	}
 }
+
+func TestPromptPick(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+		err   bool
+	}{
+		{"", 1, false},
+		{"\n", 1, false},
+		{"2\n", 2, false},
+		{" 3 \n", 3, false},
+		{"4\n", 0, true},
+		{"x\n", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := promptPick(strings.NewReader(tt.input), 3)
+		if (err != nil) != tt.err {
+			t.Errorf("promptPick(%q) error = %v, wantErr %v", tt.input, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("promptPick(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestResolveFocus(t *testing.T) {
+	cfg := &config.Config{DefaultFocus: config.FocusList{{Category: "db", Weight: 1}}}
+
+	got, err := resolveFocus(cfg, "")
+	if err != nil || got.String() != "Databases" {
+		t.Errorf("resolveFocus(default) = %q, %v; want Databases", got, err)
+	}
+	got, err = resolveFocus(cfg, "db:2,security,!ai")
+	if err != nil || got.String() != "Databases:2,Security,!AI/ML" {
+		t.Errorf("resolveFocus(flag) = %q, %v", got, err)
+	}
+	if _, err := resolveFocus(cfg, "cooking"); err == nil {
+		t.Error("expected error for unknown category")
+	}
+}
+
+func TestCapStatus(t *testing.T) {
+	today := cache.AIUsageTotal{InputTokens: 9000, OutputTokens: 1000, Cost: 0.25}
+	tests := []struct {
+		cfg  *config.AIConfig
+		want string
+	}{
+		{nil, "Today: $0.25, 10000 tokens (no daily cap)."},
+		{&config.AIConfig{DailyCap: 0.5}, "Today: $0.25 of $0.50."},
+		{&config.AIConfig{DailyCap: 0.5, DailyTokenCap: 50000}, "Today: $0.25 of $0.50, 10000 of 50000 tokens."},
+	}
+	for _, tt := range tests {
+		if got := capStatus(tt.cfg, today); got != tt.want {
+			t.Errorf("capStatus(%+v) = %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+	if got := formatCost(0.0027); got != "$0.0027" {
+		t.Errorf("formatCost(0.0027) = %q", got)
+	}
+	if !priced(nil, "gpt-4o-mini") || priced(nil, "llama3.2") || !priced(&config.AIConfig{Prices: map[string]config.Price{"llama3.2": {}}}, "llama3.2") {
+		t.Error("priced should follow the default and configured price tables")
+	}
+}
+
+func TestExportFiltersCategoryBeforeLimit(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+	db.UpsertArticles([]cache.Article{
+		{ID: "new", Source: "A", Title: "Kubernetes autoscaling", Link: "https://a.com/1", Published: now},
+		{ID: "newer", Source: "A", Title: "Rust compiler release", Link: "https://a.com/2", Published: now.Add(time.Minute)},
+		{ID: "old", Source: "A", Title: "Patching a TLS vulnerability", Link: "https://a.com/3", Published: now.Add(-time.Hour)},
+	})
+
+	opts := cache.QueryOpts{Category: "Security", Limit: 1, OrderByDate: true}
+	if err := labelUnclassified(db, opts); err != nil {
+		t.Fatalf("labelUnclassified: %v", err)
+	}
+	articles, err := db.GetArticles(opts)
+	if err != nil {
+		t.Fatalf("GetArticles: %v", err)
+	}
+	if len(articles) != 1 || articles[0].ID != "old" {
+		t.Errorf("expected the older security article despite the limit, got %+v", articles)
+	}
+}
+
+func TestExportBadFormatLeavesOutputAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digest.md")
+	flagExportFormat, flagExportOutput = "xml", path
+	defer func() { flagExportFormat, flagExportOutput = "markdown", "" }()
+
+	if err := exportCmd.RunE(exportCmd, nil); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no output file, got %v", err)
+	}
+}
+
+func TestLoadConfigAppliesCategories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(`categories:
+  - name: Observability
+    aliases: [obs]
+    keywords: [tracing]
+`), 0o644)
+	flagConfig = path
+	t.Cleanup(func() {
+		flagConfig = ""
+		classify.Configure(nil)
+	})
+
+	if _, err := loadConfig(); err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if cat, err := classify.ResolveAlias("obs"); err != nil || cat != "Observability" {
+		t.Errorf("expected the custom category configured, got %q, %v", cat, err)
+	}
+}
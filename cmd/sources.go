@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/matheuskafuri/devnews/internal/feed"
+	"github.com/matheuskafuri/devnews/internal/opml"
+	"github.com/spf13/cobra"
+)
+
+var sourcesCmd = &cobra.Command{
+	Use:   "sources",
+	Short: "Show fetch health for each configured source",
+	Long: `List every configured source with the outcome of its recent fetches:
+last success, consecutive failures, item count, latency and the last error.
+
+Sources that fail several refreshes in a row are marked FAILING — usually a
+feed that moved or was taken down.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		db, err := cache.Open(config.CachePath())
+		if err != nil {
+			return fmt.Errorf("opening cache: %w", err)
+		}
+		defer db.Close()
+
+		records, err := db.SourceHealth()
+		if err != nil {
+			return fmt.Errorf("reading source health: %w", err)
+		}
+		health := make(map[string]cache.SourceHealth, len(records))
+		for _, h := range records {
+			health[h.Source] = h
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SOURCE\tSTATUS\tITEMS\tLATENCY\tLAST SUCCESS\tFAILS\tLAST ERROR")
+		for _, s := range cfg.Sources {
+			h, ok := health[s.Name]
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				s.Name,
+				sourceStatus(s, h, ok),
+				formatCount(h.ItemCount, ok),
+				formatLatency(h.Latency, ok),
+				formatAgo(h.LastSuccess),
+				formatCount(h.ConsecutiveFailures, ok),
+				truncateError(h.LastError, 60),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var flagImportDryRun bool
+
+var sourcesImportCmd = &cobra.Command{
+	Use:   "import <file.opml>",
+	Short: "Add sources from an OPML subscription list",
+	Long: `Read feeds from an OPML file exported by another feed reader and add them
+to your config. Feeds whose URL or name is already configured are skipped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		feeds, err := opml.Parse(f)
+		if err != nil {
+			return err
+		}
+		if len(feeds) == 0 {
+			return fmt.Errorf("no feeds found in %s", args[0])
+		}
+		sources := make([]config.Source, len(feeds))
+		for i, fd := range feeds {
+			sources[i] = config.Source{Name: fd.Title, URL: fd.URL, Type: opmlSourceType(fd.Type)}
+		}
+
+		var result config.ImportResult
+		if flagImportDryRun {
+			cfg, err := loadConfig()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			_, result = config.MergeSources(cfg.Sources, sources)
+		} else {
+			result, err = config.ImportSources(flagConfig, sources)
+			if err != nil {
+				return fmt.Errorf("saving config: %w", err)
+			}
+		}
+
+		for _, s := range result.Added {
+			fmt.Printf("  + %s (%s)\n", s.Name, s.URL)
+		}
+		for _, s := range result.Skipped {
+			fmt.Printf("  - %s (%s): %s\n", s.Source.Name, s.Source.URL, s.Reason)
+		}
+		verb := "Added"
+		if flagImportDryRun {
+			verb = "Would add"
+		}
+		fmt.Printf("%s %d source(s), skipped %d.\n", verb, len(result.Added), len(result.Skipped))
+		return nil
+	},
+}
+
+var (
+	flagAddName string
+	flagAddPick int
+)
+
+var sourcesAddCmd = &cobra.Command{
+	Use:   "add <url>",
+	Short: "Add a source from a feed or site URL",
+	Long: `Add a source to your config. The URL may be the feed itself or any page
+of the site: devnews looks for feeds the page advertises and, failing that,
+tries common paths such as /feed and /rss.xml.
+
+When several feeds are found you are asked which one to add; pass --pick to
+choose without a prompt.`,
+	Example: `  devnews sources add https://jvns.ca
+  devnews sources add https://go.dev/blog --name "Go Blog"
+  devnews sources add https://example.com --pick 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+		candidates, err := feed.Discover(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		pick := flagAddPick
+		if pick == 0 && len(candidates) > 1 {
+			for i, c := range candidates {
+				fmt.Printf("  %d) %s  %s\n", i+1, candidateTitle(c), c.URL)
+			}
+			pick, err = promptPick(cmd.InOrStdin(), len(candidates))
+			if err != nil {
+				return err
+			}
+		}
+		if pick == 0 {
+			pick = 1
+		}
+		if pick < 1 || pick > len(candidates) {
+			return fmt.Errorf("--pick must be between 1 and %d", len(candidates))
+		}
+		c := candidates[pick-1]
+
+		name := flagAddName
+		if name == "" {
+			name = c.Title
+		}
+		added, err := config.AddSource(flagConfig, config.Source{Name: name, URL: c.URL, Type: c.Type})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Added %s (%s)\n", added.Name, added.URL)
+		return nil
+	},
+}
+
+// promptPick asks which of n candidates to add. An empty answer picks the
+// first.
+func promptPick(in io.Reader, n int) (int, error) {
+	fmt.Printf("Add which feed? [1-%d, default 1]: ", n)
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return 1, nil
+	}
+	pick, err := strconv.Atoi(line)
+	if err != nil || pick < 1 || pick > n {
+		return 0, fmt.Errorf("invalid choice %q", line)
+	}
+	return pick, nil
+}
+
+func candidateTitle(c feed.Candidate) string {
+	if c.Title == "" {
+		return "(untitled)"
+	}
+	return c.Title
+}
+
+// opmlSourceType maps an OPML outline type to a source type. OPML files
+// mostly say "rss" even for Atom feeds, and the RSS fetcher reads both.
+func opmlSourceType(t string) string {
+	if t == "atom" {
+		return "atom"
+	}
+	return "rss"
+}
+
+func init() {
+	sourcesImportCmd.Flags().BoolVar(&flagImportDryRun, "dry-run", false, "show what would be added without changing the config")
+	sourcesCmd.AddCommand(sourcesImportCmd)
+
+	sourcesAddCmd.Flags().StringVar(&flagAddName, "name", "", "source name (default: the feed title)")
+	sourcesAddCmd.Flags().IntVar(&flagAddPick, "pick", 0, "which discovered feed to add, when there are several")
+	sourcesCmd.AddCommand(sourcesAddCmd)
+}
+
+func sourceStatus(s config.Source, h cache.SourceHealth, fetched bool) string {
+	switch {
+	case !s.Enabled:
+		return "disabled"
+	case !fetched:
+		return "never"
+	case h.Failing():
+		return "FAILING"
+	case h.ConsecutiveFailures > 0:
+		return "error"
+	default:
+		return "ok"
+	}
+}
+
+func formatCount(n int, fetched bool) string {
+	if !fetched {
+		return "-"
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+func formatLatency(d time.Duration, fetched bool) string {
+	if !fetched {
+		return "-"
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+func formatAgo(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+func truncateError(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
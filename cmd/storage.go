@@ -17,7 +17,7 @@ var pruneCmd = &cobra.Command{
 
 Uses the retention value from config (default: 90d) unless overridden with --older-than.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load(flagConfig)
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("loading config: %w", err)
 		}
@@ -67,9 +67,15 @@ var statsCmd = &cobra.Command{
 			return fmt.Errorf("reading stats: %w", err)
 		}
 
+		version, err := db.SchemaVersion()
+		if err != nil {
+			return fmt.Errorf("reading schema version: %w", err)
+		}
+
 		fmt.Printf("Cache: %s\n", dbPath)
 		fmt.Printf("Articles: %d\n", count)
 		fmt.Printf("Size: %s\n", formatBytes(size))
+		fmt.Printf("Schema: v%d\n", version)
 		return nil
 	},
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/matheuskafuri/devnews/internal/cache"
 	"github.com/matheuskafuri/devnews/internal/classify"
 	"github.com/matheuskafuri/devnews/internal/config"
-	"github.com/matheuskafuri/devnews/internal/feed"
+	"github.com/matheuskafuri/devnews/internal/refresh"
 	"github.com/matheuskafuri/devnews/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +20,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 }
 
 func runApp(browseMode bool) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -32,23 +32,16 @@ func runApp(browseMode bool) error {
 	defer db.Close()
 
 	// Refresh if needed
-	if flagRefresh || db.NeedsRefresh(cfg.RefreshDuration()) {
+	refreshed := flagRefresh || db.NeedsRefresh(cfg.RefreshDuration())
+	if refreshed {
 		fmt.Println("Fetching feeds...")
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		result := feed.FetchAll(ctx, cfg.EnabledSources())
-		cancel()
-
-		for _, e := range result.Errors {
+		summary, err := refresh.Run(context.Background(), cfg, db, refresh.Options{})
+		for _, e := range summary.Errors {
 			fmt.Printf("  [warn] %v\n", e)
 		}
-
-		if err := db.UpsertArticles(result.Articles); err != nil {
-			return fmt.Errorf("caching articles: %w", err)
+		if err != nil {
+			return err
 		}
-		db.SetLastRefresh()
-
-		// Auto-prune old articles after refresh
-		db.Prune(cfg.RetentionDuration())
 	}
 
 	// Parse --since
@@ -65,42 +58,22 @@ func runApp(browseMode bool) error {
 	streak, _ := db.UpdateStreak()
 
 	// Initialize AI summarizer (optional, non-fatal)
-	var summarizer ai.Summarizer
+	var (
+		summarizer ai.Summarizer
+		aiErr      error
+	)
 	if cfg.AIEnabled() {
-		summarizer, _ = ai.New(cfg.AI, cfg.AIKey())
+		summarizer, aiErr = ai.New(cfg.AI, cfg.AIKey(), db)
 	}
 
 	// Generate V2 briefing (unless browse mode)
 	var briefingV2 *briefing.Briefing
 	if !browseMode {
-		// Resolve focus
-		focusCategory := ""
-		focus := flagFocus
-		if focus == "" {
-			focus = cfg.DefaultFocus
-		}
-		if focus != "" {
-			cat, err := classify.ResolveAlias(focus)
-			if err != nil {
-				return err
-			}
-			focusCategory = string(cat)
-		}
-
-		// Determine since for briefing
-		briefingSince := since
-		if briefingSince.IsZero() {
-			// Default: articles from last 24h
-			briefingSince = time.Now().Add(-24 * time.Hour)
+		focus, err := resolveFocus(cfg, flagFocus)
+		if err != nil {
+			return err
 		}
-
-		b, err := briefing.Generate(briefing.GenerateOpts{
-			DB:            db,
-			Since:         briefingSince,
-			BriefSize:     cfg.GetBriefSize(),
-			FocusCategory: focusCategory,
-		})
-		if err == nil {
+		if b, err := generateBriefing(cfg, db, focus, since); err == nil {
 			briefingV2 = b
 		}
 	}
@@ -113,12 +86,79 @@ func runApp(browseMode bool) error {
 		Since:          since,
 		Streak:         streak,
 		Summarizer:     summarizer,
+		AIErr:          aiErr,
 		BrowseMode:     browseMode,
 		BriefingV2:     briefingV2,
 		CurrentVersion: Version(),
+		ConfigPath:     flagConfig,
+		Refreshed:      refreshed,
+	})
+}
+
+// resolveFocus returns the briefing focus from the flag value, or the
+// configured default when the flag is empty, with category aliases resolved.
+func resolveFocus(cfg *config.Config, flag string) (config.FocusList, error) {
+	focus := cfg.DefaultFocus
+	if flag != "" {
+		parsed, err := config.ParseFocus(flag)
+		if err != nil {
+			return nil, err
+		}
+		focus = parsed
+	}
+	resolved := make(config.FocusList, len(focus))
+	for i, e := range focus {
+		cat, err := classify.ResolveAlias(e.Category)
+		if err != nil {
+			return nil, err
+		}
+		e.Category = string(cat)
+		resolved[i] = e
+	}
+	return resolved, nil
+}
+
+// generateBriefing builds the briefing for articles published after since,
+// or in the last 24 hours when since is zero.
+func generateBriefing(cfg *config.Config, db *cache.Cache, focus config.FocusList, since time.Time) (*briefing.Briefing, error) {
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour)
+	}
+	weights := make(map[string]float64)
+	var exclude []string
+	for _, e := range focus {
+		if e.Exclude {
+			exclude = append(exclude, e.Category)
+		} else {
+			weights[e.Category] = e.Weight
+		}
+	}
+	diversity := cfg.GetDiversity()
+	return briefing.Generate(briefing.GenerateOpts{
+		DB:            db,
+		Since:         since,
+		BriefSize:     cfg.GetBriefSize(),
+		Focus:         weights,
+		Exclude:       exclude,
+		SourceWeights: cfg.SourceWeights(),
+		Diversity: briefing.Diversity{
+			MaxPerSource:   diversity.MaxPerSource,
+			MaxPerCategory: diversity.MaxPerCategory,
+			MinCategories:  diversity.MinCategories,
+		},
 	})
 }
 
+// aiClassifier returns s when AI classification is turned on, or nil to
+// classify by keyword.
+func aiClassifier(cfg *config.Config, s ai.Summarizer) ai.Classifier {
+	if s == nil || cfg.AI == nil || !cfg.AI.Classify {
+		return nil
+	}
+	c, _ := s.(ai.Classifier)
+	return c
+}
+
 func parseSince(s string) (time.Duration, error) {
 	if len(s) > 1 && s[len(s)-1] == 'd' {
 		var days int
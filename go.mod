@@ -2,8 +2,9 @@ module github.com/matheuskafuri/devnews
 
 go 1.25.0
 
+require github.com/PuerkitoBio/goquery v1.8.0
+
 require (
-	github.com/PuerkitoBio/goquery v1.8.0 // indirect
 	github.com/adrg/xdg v0.5.3 // indirect
 	github.com/andybalholm/cascadia v1.3.1 // indirect
 	github.com/atotto/clipboard v0.1.4 // indirect
@@ -5,11 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/matheuskafuri/devnews/internal/cache"
 	"github.com/matheuskafuri/devnews/internal/config"
 )
 
@@ -34,29 +34,66 @@ type Summarizer interface {
 	SummarizeArticle(ctx context.Context, title, articleText string) (string, error)
 }
 
-// New creates a Summarizer from the given AI config.
-func New(cfg *config.AIConfig, apiKey string) (Summarizer, error) {
-	if cfg == nil || apiKey == "" {
+// Default API base URLs. Endpoint paths are appended to them, so a base
+// URL names the API version too.
+const (
+	claudeBaseURL = "https://api.anthropic.com/v1"
+	openaiBaseURL = "https://api.openai.com/v1"
+	ollamaBaseURL = "http://localhost:11434/v1"
+)
+
+// localTimeout gives models running on the user's own hardware longer to
+// answer than hosted APIs.
+const localTimeout = 2 * time.Minute
+
+// New creates a Summarizer from the given AI config. Requests go through a
+// Pool limited by the config's request rate and concurrency. When db is not
+// nil, the token usage of every request is logged to it and the config's
+// daily caps apply.
+func New(cfg *config.AIConfig, apiKey string, db *cache.Cache) (Summarizer, error) {
+	if cfg == nil || (apiKey == "" && cfg.NeedsKey()) {
 		return nil, fmt.Errorf("AI not configured")
 	}
+	var meter *Meter
+	if db != nil {
+		meter = NewMeter(cfg, db)
+	}
+	p, err := newProvider(cfg, apiKey, meter)
+	if err != nil {
+		return nil, err
+	}
+	return NewPool(p, PoolOptions{
+		RequestsPerMinute: cfg.RequestsPerMinute,
+		Concurrency:       cfg.Concurrency,
+		Meter:             meter,
+	}), nil
+}
 
+func newProvider(cfg *config.AIConfig, apiKey string, meter *Meter) (Summarizer, error) {
 	client := &http.Client{Timeout: 30 * time.Second}
+	baseURL := func(def string) string {
+		if cfg.BaseURL != "" {
+			def = cfg.BaseURL
+		}
+		return strings.TrimRight(def, "/")
+	}
 
 	switch cfg.Provider {
 	case "claude":
-		model := cfg.Model
-		if model == "" {
-			model = "claude-haiku-4-5-20251001"
-		}
-		return &claudeProvider{apiKey: apiKey, model: model, client: client}, nil
+		return &claudeProvider{apiKey: apiKey, model: cfg.ModelName(), endpoint: baseURL(claudeBaseURL) + "/messages", client: client, meter: meter}, nil
 	case "openai":
-		model := cfg.Model
-		if model == "" {
-			model = "gpt-4o-mini"
+		return &openaiProvider{name: "openai", apiKey: apiKey, model: cfg.ModelName(), endpoint: baseURL(openaiBaseURL) + "/chat/completions", client: client, meter: meter}, nil
+	case "ollama", "openai-compatible":
+		if cfg.Provider == "openai-compatible" && cfg.BaseURL == "" {
+			return nil, fmt.Errorf("AI provider %s needs a base_url", cfg.Provider)
+		}
+		if cfg.Model == "" {
+			return nil, fmt.Errorf("AI provider %s needs a model", cfg.Provider)
 		}
-		return &openaiProvider{apiKey: apiKey, model: model, client: client}, nil
+		client.Timeout = localTimeout
+		return &openaiProvider{name: cfg.Provider, apiKey: apiKey, model: cfg.Model, endpoint: baseURL(ollamaBaseURL) + "/chat/completions", client: client, meter: meter}, nil
 	default:
-		return nil, fmt.Errorf("unknown AI provider: %q (valid: claude, openai)", cfg.Provider)
+		return nil, fmt.Errorf("unknown AI provider: %q (valid: %s)", cfg.Provider, strings.Join(config.AIProviders, ", "))
 	}
 }
 
@@ -170,9 +207,11 @@ func formatArticlesForThemes(articles []ArticleSummary) string {
 // --- Claude provider ---
 
 type claudeProvider struct {
-	apiKey string
-	model  string
-	client *http.Client
+	apiKey   string
+	model    string
+	endpoint string
+	client   *http.Client
+	meter    *Meter
 }
 
 type claudeRequest struct {
@@ -190,11 +229,15 @@ type claudeResponse struct {
 	Content []struct {
 		Text string `json:"text"`
 	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
 }
 
 func (c *claudeProvider) Summarize(ctx context.Context, title, description string) (Result, error) {
 	prompt := fmt.Sprintf(summarizePrompt, title, description)
-	text, err := c.call(ctx, prompt)
+	text, err := c.call(ctx, PurposeSummarize, prompt)
 	if err != nil {
 		return Result{}, err
 	}
@@ -203,12 +246,12 @@ func (c *claudeProvider) Summarize(ctx context.Context, title, description strin
 
 func (c *claudeProvider) Brief(ctx context.Context, titles []string) (string, error) {
 	prompt := fmt.Sprintf(briefPrompt, len(titles), strings.Join(titles, "\n"))
-	return c.call(ctx, prompt)
+	return c.call(ctx, PurposeBrief, prompt)
 }
 
 func (c *claudeProvider) WhyItMatters(ctx context.Context, title, description string) (string, error) {
 	prompt := fmt.Sprintf(whyItMattersPrompt, title, description)
-	text, err := c.call(ctx, prompt)
+	text, err := c.call(ctx, PurposeWhy, prompt)
 	if err != nil {
 		return "", err
 	}
@@ -217,7 +260,7 @@ func (c *claudeProvider) WhyItMatters(ctx context.Context, title, description st
 
 func (c *claudeProvider) Themes(ctx context.Context, articles []ArticleSummary) ([]string, error) {
 	prompt := fmt.Sprintf(themesPrompt, formatArticlesForThemes(articles))
-	text, err := c.call(ctx, prompt)
+	text, err := c.call(ctx, PurposeThemes, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -226,21 +269,31 @@ func (c *claudeProvider) Themes(ctx context.Context, articles []ArticleSummary)
 
 func (c *claudeProvider) SummarizeArticle(ctx context.Context, title, articleText string) (string, error) {
 	prompt := fmt.Sprintf(articleSummaryPrompt, title, articleText)
-	text, err := c.call(ctx, prompt)
+	text, err := c.call(ctx, PurposeArticle, prompt)
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(text), nil
 }
 
-func (c *claudeProvider) call(ctx context.Context, prompt string) (string, error) {
+func (c *claudeProvider) Classify(ctx context.Context, articles []ClassifyInput, categories []string) (map[string][]string, error) {
+	return classifyBatches(ctx, articles, categories, func(ctx context.Context, prompt string) (string, error) {
+		return c.complete(ctx, PurposeClassify, prompt, classifyMaxTokens)
+	})
+}
+
+func (c *claudeProvider) call(ctx context.Context, purpose, prompt string) (string, error) {
+	return c.complete(ctx, purpose, prompt, 256)
+}
+
+func (c *claudeProvider) complete(ctx context.Context, purpose, prompt string, maxTokens int) (string, error) {
 	body, _ := json.Marshal(claudeRequest{
 		Model:     c.model,
-		MaxTokens: 256,
+		MaxTokens: maxTokens,
 		Messages:  []claudeMessage{{Role: "user", Content: prompt}},
 	})
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body))
 	if err != nil {
 		return "", err
 	}
@@ -255,14 +308,16 @@ func (c *claudeProvider) call(ctx context.Context, prompt string) (string, error
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return "", fmt.Errorf("claude API %d: %s", resp.StatusCode, string(b))
+		return "", newAPIError("claude", resp)
 	}
 
 	var cr claudeResponse
 	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
 		return "", err
 	}
+	if err := c.meter.Record(c.model, purpose, cr.Usage.InputTokens, cr.Usage.OutputTokens); err != nil {
+		return "", err
+	}
 	if len(cr.Content) == 0 {
 		return "", fmt.Errorf("empty claude response")
 	}
@@ -271,10 +326,15 @@ func (c *claudeProvider) call(ctx context.Context, prompt string) (string, error
 
 // --- OpenAI provider ---
 
+// openaiProvider talks to the OpenAI chat completions API, or to any server
+// that implements it, such as Ollama, llama.cpp or vLLM.
 type openaiProvider struct {
-	apiKey string
-	model  string
-	client *http.Client
+	name     string // provider name used in errors
+	apiKey   string // optional for self-hosted servers
+	model    string
+	endpoint string
+	client   *http.Client
+	meter    *Meter
 }
 
 type openaiRequest struct {
@@ -293,11 +353,15 @@ type openaiResponse struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
 }
 
 func (o *openaiProvider) Summarize(ctx context.Context, title, description string) (Result, error) {
 	prompt := fmt.Sprintf(summarizePrompt, title, description)
-	text, err := o.call(ctx, prompt)
+	text, err := o.call(ctx, PurposeSummarize, prompt)
 	if err != nil {
 		return Result{}, err
 	}
@@ -306,12 +370,12 @@ func (o *openaiProvider) Summarize(ctx context.Context, title, description strin
 
 func (o *openaiProvider) Brief(ctx context.Context, titles []string) (string, error) {
 	prompt := fmt.Sprintf(briefPrompt, len(titles), strings.Join(titles, "\n"))
-	return o.call(ctx, prompt)
+	return o.call(ctx, PurposeBrief, prompt)
 }
 
 func (o *openaiProvider) WhyItMatters(ctx context.Context, title, description string) (string, error) {
 	prompt := fmt.Sprintf(whyItMattersPrompt, title, description)
-	text, err := o.call(ctx, prompt)
+	text, err := o.call(ctx, PurposeWhy, prompt)
 	if err != nil {
 		return "", err
 	}
@@ -320,7 +384,7 @@ func (o *openaiProvider) WhyItMatters(ctx context.Context, title, description st
 
 func (o *openaiProvider) Themes(ctx context.Context, articles []ArticleSummary) ([]string, error) {
 	prompt := fmt.Sprintf(themesPrompt, formatArticlesForThemes(articles))
-	text, err := o.call(ctx, prompt)
+	text, err := o.call(ctx, PurposeThemes, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -329,43 +393,53 @@ func (o *openaiProvider) Themes(ctx context.Context, articles []ArticleSummary)
 
 func (o *openaiProvider) SummarizeArticle(ctx context.Context, title, articleText string) (string, error) {
 	prompt := fmt.Sprintf(articleSummaryPrompt, title, articleText)
-	text, err := o.call(ctx, prompt)
+	text, err := o.call(ctx, PurposeArticle, prompt)
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(text), nil
 }
 
-func (o *openaiProvider) call(ctx context.Context, prompt string) (string, error) {
+func (o *openaiProvider) Classify(ctx context.Context, articles []ClassifyInput, categories []string) (map[string][]string, error) {
+	return classifyBatches(ctx, articles, categories, func(ctx context.Context, prompt string) (string, error) {
+		return o.call(ctx, PurposeClassify, prompt)
+	})
+}
+
+func (o *openaiProvider) call(ctx context.Context, purpose, prompt string) (string, error) {
 	body, _ := json.Marshal(openaiRequest{
 		Model:    o.model,
 		Messages: []openaiMessage{{Role: "user", Content: prompt}},
 	})
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", o.endpoint, bytes.NewReader(body))
 	if err != nil {
 		return "", err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
 
 	resp, err := o.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("openai API error: %w", err)
+		return "", fmt.Errorf("%s API error: %w", o.name, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return "", fmt.Errorf("openai API %d: %s", resp.StatusCode, string(b))
+		return "", newAPIError(o.name, resp)
 	}
 
 	var or openaiResponse
 	if err := json.NewDecoder(resp.Body).Decode(&or); err != nil {
 		return "", err
 	}
+	if err := o.meter.Record(o.model, purpose, or.Usage.PromptTokens, or.Usage.CompletionTokens); err != nil {
+		return "", err
+	}
 	if len(or.Choices) == 0 {
-		return "", fmt.Errorf("empty openai response")
+		return "", fmt.Errorf("empty %s response", o.name)
 	}
 	return or.Choices[0].Message.Content, nil
 }
@@ -1,7 +1,13 @@
 package ai
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/matheuskafuri/devnews/internal/config"
 )
 
 func TestParseSummaryResponse(t *testing.T) {
@@ -96,3 +102,47 @@ func TestParseThemesEmpty(t *testing.T) {
 		t.Errorf("expected 0 themes for empty input, got %d", len(themes))
 	}
 }
+
+func TestNewWithBaseURL(t *testing.T) {
+	var gotPath, gotAuth, gotModel string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		var req openaiRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotModel = req.Model
+		testProviders[1].reply(w, "Local answer.")
+	}))
+	defer srv.Close()
+
+	s, err := New(&config.AIConfig{Provider: "ollama", Model: "llama3.2", BaseURL: srv.URL + "/v1/"}, "", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	got, err := s.WhyItMatters(context.Background(), "title", "desc")
+	if err != nil || got != "Local answer." {
+		t.Fatalf("WhyItMatters = %q, %v", got, err)
+	}
+	if gotPath != "/v1/chat/completions" || gotAuth != "" || gotModel != "llama3.2" {
+		t.Errorf("expected a keyless request for llama3.2 to /v1/chat/completions, got %q %q %q", gotPath, gotAuth, gotModel)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.AIConfig
+		key  string
+	}{
+		{"hosted without key", config.AIConfig{Provider: "claude"}, ""},
+		{"compatible without base_url", config.AIConfig{Provider: "openai-compatible", Model: "m"}, ""},
+		{"self-hosted without model", config.AIConfig{Provider: "ollama"}, ""},
+		{"unknown provider", config.AIConfig{Provider: "gemini"}, "key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(&tt.cfg, tt.key, nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ClassifyInput is an article to be classified.
+type ClassifyInput struct {
+	ID          string
+	Title       string
+	Description string
+}
+
+// Classifier assigns articles to categories. The providers returned by New
+// implement it; callers check for it with a type assertion.
+type Classifier interface {
+	// Classify returns up to three categories per article id, best first,
+	// taken from categories. Every article in a batch the model answered
+	// has an entry, with no categories when it could not be placed. On
+	// error, the results of the batches that succeeded are returned along
+	// with it; articles without an entry were not answered.
+	Classify(ctx context.Context, articles []ClassifyInput, categories []string) (map[string][]string, error)
+}
+
+const (
+	// classifyBatchSize is the number of articles sent in one request.
+	classifyBatchSize = 20
+	// classifyMaxTokens leaves room for one answer line per article.
+	classifyMaxTokens = 1024
+	// classifyDescriptionLimit truncates descriptions to keep prompts small.
+	classifyDescriptionLimit = 200
+	maxCategoriesPerArticle  = 3
+)
+
+const classifyPrompt = `Classify each of these engineering blog posts into 1-3 of the following categories, most relevant first. Use only these category names, spelled exactly as given:
+%s
+
+Articles:
+%s
+Respond with one line per article in the form "<number>: <category>, <category>". No other text.`
+
+// classifyBatches classifies articles in batches of classifyBatchSize using
+// call to send each prompt. It stops at the first failed batch.
+func classifyBatches(ctx context.Context, articles []ClassifyInput, categories []string, call func(context.Context, string) (string, error)) (map[string][]string, error) {
+	results := make(map[string][]string, len(articles))
+	if len(categories) == 0 {
+		return results, nil
+	}
+	for start := 0; start < len(articles); start += classifyBatchSize {
+		batch := articles[start:min(start+classifyBatchSize, len(articles))]
+		text, err := call(ctx, formatClassifyPrompt(batch, categories))
+		if err != nil {
+			return results, fmt.Errorf("classifying articles: %w", err)
+		}
+		answers := parseClassifyResponse(text, len(batch), categories)
+		for i, a := range batch {
+			results[a.ID] = answers[i]
+		}
+	}
+	return results, nil
+}
+
+func formatClassifyPrompt(batch []ClassifyInput, categories []string) string {
+	var sb strings.Builder
+	for i, a := range batch {
+		fmt.Fprintf(&sb, "%d. %s", i+1, a.Title)
+		if desc := truncateRunes(strings.Join(strings.Fields(a.Description), " "), classifyDescriptionLimit); desc != "" {
+			sb.WriteString(" — ")
+			sb.WriteString(desc)
+		}
+		sb.WriteString("\n")
+	}
+	return fmt.Sprintf(classifyPrompt, strings.Join(categories, ", "), sb.String())
+}
+
+// parseClassifyResponse maps each "<number>: <categories>" line to the
+// batch index it answers. Categories are matched case-insensitively against
+// the allowed set and returned in its spelling; anything else is dropped.
+func parseClassifyResponse(text string, n int, categories []string) map[int][]string {
+	allowed := make(map[string]string, len(categories))
+	for _, c := range categories {
+		allowed[strings.ToLower(c)] = c
+	}
+
+	results := make(map[int][]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+		i, err := strconv.Atoi(line[:digits])
+		if err != nil || i < 1 || i > n {
+			continue
+		}
+		// Accept "1:", "1." and "1)" after the number.
+		rest := strings.TrimLeft(line[digits:], ":.) ")
+		var cats []string
+		for _, name := range strings.Split(rest, ",") {
+			cat, ok := allowed[strings.ToLower(strings.TrimSpace(name))]
+			if !ok || slices.Contains(cats, cat) {
+				continue
+			}
+			cats = append(cats, cat)
+			if len(cats) == maxCategoriesPerArticle {
+				break
+			}
+		}
+		if len(cats) > 0 {
+			results[i-1] = cats
+		}
+	}
+	return results
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + "..."
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testCategories = []string{"AI/ML", "Databases", "Infrastructure", "Security"}
+
+func TestParseClassifyResponse(t *testing.T) {
+	input := `1: Databases, infrastructure
+2. AI/ML
+3: Frontend, Security, Security
+4) Databases, Infrastructure, Security, AI/ML
+9: Databases
+Sure, here you go:`
+
+	got := parseClassifyResponse(input, 5, testCategories)
+	want := map[int][]string{
+		0: {"Databases", "Infrastructure"},
+		1: {"AI/ML"},
+		2: {"Security"},
+		3: {"Databases", "Infrastructure", "Security"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestClassifyBatches(t *testing.T) {
+	var articles []ClassifyInput
+	for i := 0; i < classifyBatchSize+5; i++ {
+		articles = append(articles, ClassifyInput{ID: fmt.Sprintf("a%d", i), Title: fmt.Sprintf("Post %d", i)})
+	}
+
+	var prompts []string
+	call := func(_ context.Context, prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		if len(prompts) == 2 {
+			return "", errors.New("rate limited")
+		}
+		return "1: Databases\n2: Security, AI/ML", nil
+	}
+
+	got, err := classifyBatches(context.Background(), articles, testCategories, call)
+	if err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("expected the batch error, got %v", err)
+	}
+	if len(prompts) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(prompts))
+	}
+	if !strings.Contains(prompts[0], "Databases, Infrastructure") || !strings.Contains(prompts[0], "1. Post 0") {
+		t.Errorf("expected categories and numbered titles in the prompt, got:\n%s", prompts[0])
+	}
+	// Every article of the answered batch has an entry; the failed batch's
+	// articles have none.
+	want := map[string][]string{"a0": {"Databases"}, "a1": {"Security", "AI/ML"}}
+	for i := 2; i < classifyBatchSize; i++ {
+		want[fmt.Sprintf("a%d", i)] = nil
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the first batch's results, got %v", got)
+	}
+}
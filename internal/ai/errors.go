@@ -0,0 +1,75 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is an error response from a provider's API.
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+	// RetryAfter is how long the provider asked us to wait before trying
+	// again, from the Retry-After header; zero when it sent none.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// Temporary reports whether the request may succeed if sent again: the
+// provider is rate limiting, overloaded or failing on its side.
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Permanent reports whether err is an API error that sending the same
+// request again will not fix, such as a rejected key or a malformed request.
+func Permanent(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && !apiErr.Temporary()
+}
+
+// newAPIError builds an APIError from a non-200 response.
+func newAPIError(provider string, resp *http.Response) *APIError {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &APIError{
+		Provider:   provider,
+		StatusCode: resp.StatusCode,
+		Body:       string(b),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// retryable reports whether err is worth retrying: a temporary API error or
+// a network failure.
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
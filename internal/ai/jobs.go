@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// JobResult is the output of a job; only the field for its kind is set.
+type JobResult struct {
+	Summary      Result
+	WhyItMatters string
+}
+
+// RunJob performs a queued job with s and saves the result to the article.
+// A job that succeeds is removed from the queue; a failure is recorded on
+// the job so it can be retried later.
+func RunJob(ctx context.Context, s Summarizer, db *cache.Cache, job cache.AIJob) (JobResult, error) {
+	a := job.Article
+	var res JobResult
+	var err error
+	switch job.Kind {
+	case cache.AIJobSummarize:
+		res.Summary, err = s.Summarize(ctx, a.Title, a.Description)
+		if err == nil {
+			err = db.UpdateArticleSummary(a.ID, res.Summary.Summary, strings.Join(res.Summary.Tags, ", "))
+		}
+	case cache.AIJobWhyItMatters:
+		res.WhyItMatters, err = s.WhyItMatters(ctx, a.Title, a.Description)
+		if err == nil && res.WhyItMatters != "" {
+			err = db.UpdateArticleWhyItMatters(a.ID, res.WhyItMatters)
+		}
+	default:
+		err = fmt.Errorf("unknown AI job kind %q", job.Kind)
+	}
+
+	if err != nil {
+		// A job refused by the daily cap was never tried; leave it queued
+		// for tomorrow without counting an attempt.
+		if !errors.Is(err, ErrDailyCap) {
+			db.FailAIJob(a.ID, job.Kind, err)
+		}
+		return JobResult{}, err
+	}
+	db.FinishAIJob(a.ID, job.Kind)
+	return res, nil
+}
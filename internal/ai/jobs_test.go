@@ -0,0 +1,52 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// jobSummarizer summarizes every article and fails WhyItMatters.
+type jobSummarizer struct {
+	Summarizer
+}
+
+func (jobSummarizer) Summarize(_ context.Context, title, _ string) (Result, error) {
+	return Result{Summary: "About " + title, Tags: []string{"rust", "performance"}}, nil
+}
+
+func (jobSummarizer) WhyItMatters(context.Context, string, string) (string, error) {
+	return "", errors.New("overloaded")
+}
+
+func TestRunJob(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	article := cache.Article{ID: "a1", Source: "A", Title: "Rust rewrite", Link: "https://example.com/a1"}
+	db.UpsertArticles([]cache.Article{article})
+	db.EnqueueAIJob("a1", cache.AIJobSummarize)
+	db.EnqueueAIJob("a1", cache.AIJobWhyItMatters)
+
+	res, err := RunJob(context.Background(), jobSummarizer{}, db, cache.AIJob{Article: article, Kind: cache.AIJobSummarize})
+	if err != nil || res.Summary.Summary != "About Rust rewrite" {
+		t.Fatalf("RunJob = %+v, %v", res, err)
+	}
+	if _, err := RunJob(context.Background(), jobSummarizer{}, db, cache.AIJob{Article: article, Kind: cache.AIJobWhyItMatters}); err == nil {
+		t.Fatal("expected the WhyItMatters job to fail")
+	}
+
+	stored, _ := db.FindArticle("a1")
+	if stored.Summary != "About Rust rewrite" || stored.Tags != "rust, performance" {
+		t.Errorf("expected the summary saved, got %q %q", stored.Summary, stored.Tags)
+	}
+	jobs, _ := db.PendingAIJobs(0)
+	if len(jobs) != 1 || jobs[0].Kind != cache.AIJobWhyItMatters || jobs[0].Attempts != 1 || jobs[0].LastError != "overloaded" {
+		t.Errorf("expected only the failed job left queued, got %+v", jobs)
+	}
+}
@@ -0,0 +1,267 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for PoolOptions fields left at zero.
+const (
+	DefaultRequestsPerMinute = 50
+	DefaultConcurrency       = 4
+	DefaultMaxAttempts       = 4
+	defaultBaseBackoff       = time.Second
+	defaultMaxBackoff        = 30 * time.Second
+)
+
+// PoolOptions configures a Pool. Zero fields take the defaults above.
+type PoolOptions struct {
+	RequestsPerMinute int
+	Concurrency       int // requests in flight at once
+	MaxAttempts       int // tries per request, including the first
+	// Meter, when set, refuses requests once a daily cap is reached.
+	Meter *Meter
+}
+
+// Pool is a Summarizer that sends every request through a shared budget:
+// at most Concurrency requests in flight and RequestsPerMinute started per
+// minute. Rate-limited and failed requests are retried with exponential
+// backoff, waiting at least as long as the provider's Retry-After asks,
+// which also holds back every other request. Identical requests made while
+// one is in flight share its result. Once the Meter's daily cap is reached,
+// requests fail with ErrDailyCap without being sent.
+type Pool struct {
+	s           Summarizer
+	sem         chan struct{}
+	maxAttempts int
+	meter       *Meter
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+	sleep       func(ctx context.Context, d time.Duration) error
+
+	mu       sync.Mutex
+	interval time.Duration // between request starts
+	next     time.Time     // earliest start of the next request
+	inflight map[string]*flight
+}
+
+// flight is a request in progress that identical requests wait on.
+type flight struct {
+	done chan struct{}
+	val  any
+	err  error
+}
+
+// NewPool wraps s in a Pool.
+func NewPool(s Summarizer, opts PoolOptions) *Pool {
+	if opts.RequestsPerMinute <= 0 {
+		opts.RequestsPerMinute = DefaultRequestsPerMinute
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	return &Pool{
+		s:           s,
+		sem:         make(chan struct{}, opts.Concurrency),
+		maxAttempts: opts.MaxAttempts,
+		meter:       opts.Meter,
+		baseBackoff: defaultBaseBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		now:         time.Now,
+		sleep:       sleep,
+		interval:    time.Minute / time.Duration(opts.RequestsPerMinute),
+		inflight:    make(map[string]*flight),
+	}
+}
+
+func (p *Pool) Summarize(ctx context.Context, title, description string) (Result, error) {
+	return run(ctx, p, key("summarize", title, description), func(ctx context.Context) (Result, error) {
+		return p.s.Summarize(ctx, title, description)
+	})
+}
+
+func (p *Pool) Brief(ctx context.Context, titles []string) (string, error) {
+	return run(ctx, p, key("brief", titles...), func(ctx context.Context) (string, error) {
+		return p.s.Brief(ctx, titles)
+	})
+}
+
+func (p *Pool) WhyItMatters(ctx context.Context, title, description string) (string, error) {
+	return run(ctx, p, key("why", title, description), func(ctx context.Context) (string, error) {
+		return p.s.WhyItMatters(ctx, title, description)
+	})
+}
+
+func (p *Pool) Themes(ctx context.Context, articles []ArticleSummary) ([]string, error) {
+	parts := make([]string, len(articles))
+	for i, a := range articles {
+		parts[i] = a.Title + "\x1f" + a.Category
+	}
+	return run(ctx, p, key("themes", parts...), func(ctx context.Context) ([]string, error) {
+		return p.s.Themes(ctx, articles)
+	})
+}
+
+func (p *Pool) SummarizeArticle(ctx context.Context, title, articleText string) (string, error) {
+	return run(ctx, p, key("article", title, articleText), func(ctx context.Context) (string, error) {
+		return p.s.SummarizeArticle(ctx, title, articleText)
+	})
+}
+
+// Classify sends each batch through the pool. It fails when the wrapped
+// Summarizer is not a Classifier.
+func (p *Pool) Classify(ctx context.Context, articles []ClassifyInput, categories []string) (map[string][]string, error) {
+	c, ok := p.s.(Classifier)
+	if !ok {
+		return nil, errors.New("AI provider cannot classify articles")
+	}
+	results := make(map[string][]string, len(articles))
+	for start := 0; start < len(articles); start += classifyBatchSize {
+		batch := articles[start:min(start+classifyBatchSize, len(articles))]
+		parts := []string{strings.Join(categories, ",")}
+		for _, a := range batch {
+			parts = append(parts, a.ID)
+		}
+		got, err := run(ctx, p, key("classify", parts...), func(ctx context.Context) (map[string][]string, error) {
+			return c.Classify(ctx, batch, categories)
+		})
+		for id, cats := range got {
+			results[id] = cats
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+func key(kind string, parts ...string) string {
+	return kind + "\x00" + strings.Join(parts, "\x00")
+}
+
+// run performs fn through the pool, or waits for an identical request
+// already in flight. If that request was cancelled by its own caller, it is
+// sent again for waiters whose context is still live. It is a function
+// because methods cannot be generic.
+func run[T any](ctx context.Context, p *Pool, k string, fn func(context.Context) (T, error)) (T, error) {
+	p.mu.Lock()
+	for {
+		f, ok := p.inflight[k]
+		if !ok {
+			break
+		}
+		p.mu.Unlock()
+		select {
+		case <-f.done:
+			if !isContextErr(f.err) || ctx.Err() != nil {
+				v, _ := f.val.(T)
+				return v, f.err
+			}
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		p.mu.Lock()
+	}
+	f := &flight{done: make(chan struct{})}
+	p.inflight[k] = f
+	p.mu.Unlock()
+
+	v, err := retry(ctx, p, fn)
+	f.val, f.err = v, err
+
+	p.mu.Lock()
+	delete(p.inflight, k)
+	p.mu.Unlock()
+	close(f.done)
+	return v, err
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// retry calls fn until it succeeds, fails permanently, or runs out of
+// attempts, waiting for a rate-limit slot before each call. No call is made
+// once the daily cap is reached.
+func retry[T any](ctx context.Context, p *Pool, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	for attempt := 0; ; attempt++ {
+		if err := p.meter.Allow(); err != nil {
+			return zero, err
+		}
+		if err := p.acquire(ctx); err != nil {
+			return zero, err
+		}
+		v, err := fn(ctx)
+		<-p.sem
+		if err == nil || !retryable(err) || attempt+1 >= p.maxAttempts || ctx.Err() != nil {
+			return v, err
+		}
+
+		wait := min(p.baseBackoff<<attempt, p.maxBackoff)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = max(wait, apiErr.RetryAfter)
+			p.holdUntil(p.now().Add(apiErr.RetryAfter))
+		}
+		if err := p.sleep(ctx, wait); err != nil {
+			return zero, err
+		}
+	}
+}
+
+// acquire waits for a free slot and for the next start time allowed by the
+// rate limit.
+func (p *Pool) acquire(ctx context.Context) error {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.mu.Lock()
+	now := p.now()
+	start := p.next
+	if start.Before(now) {
+		start = now
+	}
+	p.next = start.Add(p.interval)
+	p.mu.Unlock()
+
+	if err := p.sleep(ctx, start.Sub(now)); err != nil {
+		<-p.sem
+		return err
+	}
+	return nil
+}
+
+// holdUntil delays every request not yet started until t.
+func (p *Pool) holdUntil(t time.Time) {
+	p.mu.Lock()
+	if p.next.Before(t) {
+		p.next = t
+	}
+	p.mu.Unlock()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
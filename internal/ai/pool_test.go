@@ -0,0 +1,293 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testProviders builds each provider against a test server whose handler
+// answers with text.
+var testProviders = []struct {
+	name  string
+	reply func(w http.ResponseWriter, text string)
+	new   func(endpoint string) Summarizer
+}{
+	{
+		name: "claude",
+		reply: func(w http.ResponseWriter, text string) {
+			fmt.Fprintf(w, `{"content":[{"text":%q}]}`, text)
+		},
+		new: func(endpoint string) Summarizer {
+			return &claudeProvider{apiKey: "key", model: "m", endpoint: endpoint, client: http.DefaultClient}
+		},
+	},
+	{
+		name: "openai",
+		reply: func(w http.ResponseWriter, text string) {
+			fmt.Fprintf(w, `{"choices":[{"message":{"content":%q}}]}`, text)
+		},
+		new: func(endpoint string) Summarizer {
+			return &openaiProvider{name: "openai", apiKey: "key", model: "m", endpoint: endpoint, client: http.DefaultClient}
+		},
+	},
+}
+
+// testPool wraps s in a pool on a fake clock that sleeping advances, and
+// records every wait.
+func testPool(s Summarizer, opts PoolOptions) (*Pool, *[]time.Duration) {
+	if opts.RequestsPerMinute == 0 {
+		opts.RequestsPerMinute = 60000
+	}
+	p := NewPool(s, opts)
+	var (
+		mu    sync.Mutex
+		clock = time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+		waits []time.Duration
+	)
+	p.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return clock
+	}
+	p.sleep = func(ctx context.Context, d time.Duration) error {
+		if d > 0 {
+			mu.Lock()
+			clock = clock.Add(d)
+			waits = append(waits, d)
+			mu.Unlock()
+		}
+		return ctx.Err()
+	}
+	return p, &waits
+}
+
+func TestPoolRetriesRateLimitedRequests(t *testing.T) {
+	for _, tp := range testProviders {
+		t.Run(tp.name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("x-api-key") != "key" && r.Header.Get("Authorization") != "Bearer key" {
+					t.Errorf("missing API key header")
+				}
+				switch hits.Add(1) {
+				case 1:
+					w.Header().Set("Retry-After", "7")
+					w.WriteHeader(http.StatusTooManyRequests)
+				case 2:
+					w.WriteHeader(http.StatusServiceUnavailable)
+				default:
+					tp.reply(w, "  It matters.  ")
+				}
+			}))
+			defer srv.Close()
+
+			p, waits := testPool(tp.new(srv.URL), PoolOptions{})
+			got, err := p.WhyItMatters(context.Background(), "title", "desc")
+			if err != nil || got != "It matters." {
+				t.Fatalf("WhyItMatters = %q, %v", got, err)
+			}
+			if hits.Load() != 3 {
+				t.Errorf("expected 3 requests, got %d", hits.Load())
+			}
+			// Retry-After is honored, then the backoff doubles.
+			if len(*waits) != 2 || (*waits)[0] != 7*time.Second || (*waits)[1] != 2*time.Second {
+				t.Errorf("expected waits of 7s and 2s, got %v", *waits)
+			}
+		})
+	}
+}
+
+func TestPoolStopsOnClientError(t *testing.T) {
+	for _, tp := range testProviders {
+		t.Run(tp.name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				http.Error(w, "bad key", http.StatusUnauthorized)
+			}))
+			defer srv.Close()
+
+			p, _ := testPool(tp.new(srv.URL), PoolOptions{})
+			_, err := p.Summarize(context.Background(), "title", "desc")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Provider != tp.name {
+				t.Fatalf("expected a 401 APIError, got %v", err)
+			}
+			if hits.Load() != 1 {
+				t.Errorf("expected no retries, got %d requests", hits.Load())
+			}
+		})
+	}
+}
+
+func TestPoolGivesUpAfterMaxAttempts(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	p, _ := testPool(testProviders[0].new(srv.URL), PoolOptions{MaxAttempts: 3})
+	if _, err := p.Brief(context.Background(), []string{"a"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if hits.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", hits.Load())
+	}
+}
+
+// blockingSummarizer answers WhyItMatters once release is closed, counting
+// calls and the most calls running at once.
+type blockingSummarizer struct {
+	Summarizer
+	release chan struct{}
+
+	mu       sync.Mutex
+	calls    int
+	running  int
+	maxInUse int
+}
+
+func (b *blockingSummarizer) WhyItMatters(ctx context.Context, title, _ string) (string, error) {
+	b.mu.Lock()
+	b.calls++
+	b.running++
+	b.maxInUse = max(b.maxInUse, b.running)
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.running--
+		b.mu.Unlock()
+	}()
+	select {
+	case <-b.release:
+		return "why " + title, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestPoolDeduplicatesInFlightRequests(t *testing.T) {
+	b := &blockingSummarizer{release: make(chan struct{})}
+	p, _ := testPool(b, PoolOptions{})
+
+	var wg sync.WaitGroup
+	results := make([]string, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = p.WhyItMatters(context.Background(), "same", "desc")
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(b.release)
+	wg.Wait()
+
+	if b.calls != 1 {
+		t.Errorf("expected 1 call for identical requests, got %d", b.calls)
+	}
+	for i, r := range results {
+		if r != "why same" {
+			t.Errorf("result %d = %q", i, r)
+		}
+	}
+}
+
+func TestPoolRetriesForWaitersWhenFirstCallerCancels(t *testing.T) {
+	b := &blockingSummarizer{release: make(chan struct{})}
+	p, _ := testPool(b, PoolOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := p.WhyItMatters(ctx, "same", "desc")
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	second := make(chan string, 1)
+	go func() {
+		got, err := p.WhyItMatters(context.Background(), "same", "desc")
+		if err != nil {
+			t.Errorf("waiter got the first caller's error: %v", err)
+		}
+		second <- got
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the first caller to be cancelled, got %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(b.release)
+	if got := <-second; got != "why same" {
+		t.Errorf("waiter got %q", got)
+	}
+	if b.calls != 2 {
+		t.Errorf("expected the request sent again for the waiter, got %d calls", b.calls)
+	}
+}
+
+func TestPoolLimitsConcurrency(t *testing.T) {
+	b := &blockingSummarizer{release: make(chan struct{})}
+	p, _ := testPool(b, PoolOptions{Concurrency: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p.WhyItMatters(context.Background(), fmt.Sprintf("title %d", i), "")
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(b.release)
+	wg.Wait()
+
+	if b.calls != 6 || b.maxInUse != 2 {
+		t.Errorf("expected 6 calls, at most 2 at once; got %d calls, %d at once", b.calls, b.maxInUse)
+	}
+}
+
+func TestPoolLimitsRequestRate(t *testing.T) {
+	b := &blockingSummarizer{release: make(chan struct{})}
+	close(b.release)
+	p := NewPool(b, PoolOptions{RequestsPerMinute: 600}) // one every 100ms
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		p.WhyItMatters(context.Background(), fmt.Sprintf("title %d", i), "")
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected 3 requests to take at least 200ms, took %v", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"-5", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
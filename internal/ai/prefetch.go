@@ -0,0 +1,86 @@
+package ai
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// PrefetchJobs returns the jobs that fill in the summary and "why it
+// matters" text of the count newest unread articles from sources, skipping
+// text they already have and jobs that failed too often. At most budget jobs
+// are returned; zero means no limit.
+func PrefetchJobs(db *cache.Cache, sources []string, count, budget int) ([]cache.AIJob, error) {
+	if count <= 0 || len(sources) == 0 {
+		return nil, nil
+	}
+	articles, err := db.GetArticles(cache.QueryOpts{Sources: sources, Unread: true, Limit: count})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []cache.AIJob
+	add := func(a cache.Article, kind string) error {
+		givenUp, err := db.AIJobGivenUp(a.ID, kind)
+		if err == nil && !givenUp {
+			jobs = append(jobs, cache.AIJob{Article: a, Kind: kind})
+		}
+		return err
+	}
+	for _, a := range articles {
+		if a.Summary == "" {
+			if err := add(a, cache.AIJobSummarize); err != nil {
+				return nil, err
+			}
+		}
+		if a.WhyItMatters == "" {
+			if err := add(a, cache.AIJobWhyItMatters); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if budget > 0 && len(jobs) > budget {
+		jobs = jobs[:budget]
+	}
+	return jobs, nil
+}
+
+// RunJobs queues jobs in the cache and runs them all at once with s, which
+// should be a Pool so that its limits apply. Jobs that already failed
+// MaxAIJobAttempts times are skipped. progress, when not nil, is called
+// after each job finishes with the number finished so far. RunJobs returns
+// the number of jobs that failed.
+func RunJobs(ctx context.Context, s Summarizer, db *cache.Cache, jobs []cache.AIJob, progress func(done, total int)) int {
+	jobs = slices.DeleteFunc(slices.Clone(jobs), func(j cache.AIJob) bool {
+		givenUp, _ := db.AIJobGivenUp(j.Article.ID, j.Kind)
+		return givenUp
+	})
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		done   int
+		failed int
+	)
+	for _, job := range jobs {
+		db.EnqueueAIJob(job.Article.ID, job.Kind)
+		wg.Add(1)
+		go func(job cache.AIJob) {
+			defer wg.Done()
+			_, err := RunJob(ctx, s, db, job)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if err != nil {
+				failed++
+			}
+			if progress != nil {
+				progress(done, len(jobs))
+			}
+		}(job)
+	}
+	wg.Wait()
+	return failed
+}
@@ -0,0 +1,102 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+func TestPrefetchJobs(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	db.UpsertArticles([]cache.Article{
+		{ID: "new", Source: "A", Title: "Newest", Link: "https://a.com/1", Published: now},
+		{ID: "summarized", Source: "A", Title: "Summarized", Link: "https://a.com/2", Published: now.Add(-time.Hour)},
+		{ID: "read", Source: "A", Title: "Read", Link: "https://a.com/3", Published: now.Add(-2 * time.Hour)},
+		{ID: "disabled", Source: "B", Title: "Other source", Link: "https://b.com/1", Published: now},
+		{ID: "old", Source: "A", Title: "Oldest", Link: "https://a.com/4", Published: now.Add(-3 * time.Hour)},
+	})
+	db.UpdateArticleSummary("summarized", "Already done.", "")
+	db.MarkArticleRead("read")
+
+	kinds := func(jobs []cache.AIJob) []string {
+		var out []string
+		for _, j := range jobs {
+			out = append(out, j.Article.ID+"/"+j.Kind)
+		}
+		return out
+	}
+
+	jobs, err := PrefetchJobs(db, []string{"A"}, 3, 0)
+	if err != nil {
+		t.Fatalf("PrefetchJobs: %v", err)
+	}
+	want := []string{"new/summarize", "new/why", "summarized/why", "old/summarize", "old/why"}
+	if got := kinds(jobs); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	jobs, _ = PrefetchJobs(db, []string{"A"}, 3, 2)
+	if got := kinds(jobs); !slices.Equal(got, want[:2]) {
+		t.Errorf("expected the budget to cap jobs, got %v", got)
+	}
+
+	// A job that failed too often is not picked again.
+	db.EnqueueAIJob("new", cache.AIJobSummarize)
+	for i := 0; i < cache.MaxAIJobAttempts; i++ {
+		db.FailAIJob("new", cache.AIJobSummarize, errors.New("overloaded"))
+	}
+	jobs, _ = PrefetchJobs(db, []string{"A"}, 3, 0)
+	if got := kinds(jobs); !slices.Equal(got, want[1:]) {
+		t.Errorf("expected the given-up job skipped, got %v", got)
+	}
+	if failed := RunJobs(context.Background(), jobSummarizer{}, db, []cache.AIJob{{Article: cache.Article{ID: "new"}, Kind: cache.AIJobSummarize}}, nil); failed != 0 {
+		t.Errorf("expected RunJobs to skip the given-up job, got %d failures", failed)
+	}
+	if stored, _ := db.FindArticle("new"); stored.Summary != "" {
+		t.Errorf("expected the given-up job not to run, got summary %q", stored.Summary)
+	}
+}
+
+func TestRunJobs(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	articles := []cache.Article{
+		{ID: "a", Source: "A", Title: "A", Link: "https://a.com/1"},
+		{ID: "b", Source: "A", Title: "B", Link: "https://a.com/2"},
+	}
+	db.UpsertArticles(articles)
+	jobs := []cache.AIJob{
+		{Article: articles[0], Kind: cache.AIJobSummarize},
+		{Article: articles[1], Kind: cache.AIJobSummarize},
+		{Article: articles[1], Kind: cache.AIJobWhyItMatters}, // jobSummarizer fails these
+	}
+
+	var calls, last int
+	failed := RunJobs(context.Background(), jobSummarizer{}, db, jobs, func(done, total int) {
+		calls++
+		last = done
+		if total != 3 {
+			t.Errorf("total = %d", total)
+		}
+	})
+	if failed != 1 || calls != 3 || last != 3 {
+		t.Errorf("expected 1 failure and 3 progress calls ending at 3, got %d, %d, %d", failed, calls, last)
+	}
+	if pending, _ := db.PendingAIJobs(0); len(pending) != 1 || pending[0].Kind != cache.AIJobWhyItMatters {
+		t.Errorf("expected the failed job left queued, got %+v", pending)
+	}
+}
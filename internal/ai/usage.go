@@ -0,0 +1,108 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+)
+
+// Purposes of AI requests, as recorded in the usage log.
+const (
+	PurposeSummarize = "summarize"
+	PurposeWhy       = "why"
+	PurposeBrief     = "brief"
+	PurposeThemes    = "themes"
+	PurposeArticle   = "article"
+	PurposeClassify  = "classify"
+)
+
+// ErrDailyCap is returned instead of making a request once the day's AI
+// usage has reached the configured cap.
+var ErrDailyCap = errors.New("daily AI usage cap reached")
+
+// Meter records the token usage of AI requests in the cache and enforces
+// the daily caps. A nil Meter records nothing and allows everything.
+type Meter struct {
+	db       *cache.Cache
+	cfg      *config.AIConfig
+	costCap  float64
+	tokenCap int
+	now      func() time.Time
+
+	mu        sync.Mutex
+	recordErr error // first failed usage write; the caps refuse requests after it
+}
+
+// NewMeter returns a Meter for the provider in cfg that logs to db.
+func NewMeter(cfg *config.AIConfig, db *cache.Cache) *Meter {
+	return &Meter{
+		db:       db,
+		cfg:      cfg,
+		costCap:  cfg.DailyCap,
+		tokenCap: cfg.DailyTokenCap,
+		now:      time.Now,
+	}
+}
+
+// Cost estimates the cost in USD of a request to model, from the config's
+// prices or config.DefaultPrices. Unpriced models, such as self-hosted
+// ones, cost nothing; config validation keeps them from being used with a
+// daily_cap.
+func (m *Meter) Cost(model string, inputTokens, outputTokens int) float64 {
+	p, _ := m.cfg.PriceOf(model)
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6
+}
+
+// Allow returns ErrDailyCap once today's usage has reached a cap, or once
+// usage could not be recorded, since the caps can no longer be enforced.
+func (m *Meter) Allow() error {
+	if m == nil || (m.costCap <= 0 && m.tokenCap <= 0) {
+		return nil
+	}
+	m.mu.Lock()
+	recordErr := m.recordErr
+	m.mu.Unlock()
+	if recordErr != nil {
+		return fmt.Errorf("%w: usage could not be recorded: %v", ErrDailyCap, recordErr)
+	}
+	total, err := m.db.AIUsageOn(m.now())
+	if err != nil {
+		return err
+	}
+	if m.costCap > 0 && total.Cost >= m.costCap {
+		return fmt.Errorf("%w: $%.2f spent of $%.2f", ErrDailyCap, total.Cost, m.costCap)
+	}
+	if m.tokenCap > 0 && total.Tokens() >= m.tokenCap {
+		return fmt.Errorf("%w: %d tokens used of %d", ErrDailyCap, total.Tokens(), m.tokenCap)
+	}
+	return nil
+}
+
+// Record logs the usage of a request made for purpose.
+func (m *Meter) Record(model, purpose string, inputTokens, outputTokens int) error {
+	if m == nil {
+		return nil
+	}
+	err := m.db.RecordAIUsage(cache.AIUsage{
+		At:           m.now(),
+		Provider:     m.cfg.Provider,
+		Model:        model,
+		Purpose:      purpose,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		Cost:         m.Cost(model, inputTokens, outputTokens),
+	})
+	if err != nil {
+		m.mu.Lock()
+		if m.recordErr == nil {
+			m.recordErr = err
+		}
+		m.mu.Unlock()
+		return fmt.Errorf("recording AI usage: %w", err)
+	}
+	return nil
+}
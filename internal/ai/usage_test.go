@@ -0,0 +1,167 @@
+package ai
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+)
+
+func testCache(t *testing.T) *cache.Cache {
+	t.Helper()
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// usageServer answers every request from provider with text and a usage of
+// 1000 input and 200 output tokens, counting requests.
+func usageServer(t *testing.T, provider string, hits *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if provider == "claude" {
+			fmt.Fprint(w, `{"content":[{"text":"Rust"}],"usage":{"input_tokens":1000,"output_tokens":200}}`)
+			return
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"content":"Rust"}}],"usage":{"prompt_tokens":1000,"completion_tokens":200}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProvidersRecordUsage(t *testing.T) {
+	tests := []struct {
+		provider string
+		model    string
+		cost     float64
+	}{
+		{"claude", "claude-haiku-4-5-20251001", 0.002},
+		{"openai", "gpt-4o-mini", 0.00027},
+		{"ollama", "llama3.2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := usageServer(t, tt.provider, &hits)
+			db := testCache(t)
+			s, err := New(&config.AIConfig{Provider: tt.provider, APIKey: "key", Model: tt.model, BaseURL: srv.URL}, "key", db)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if _, err := s.Themes(context.Background(), []ArticleSummary{{Title: "Rust"}}); err != nil {
+				t.Fatalf("Themes: %v", err)
+			}
+
+			rows, err := db.AIUsageSince(time.Now())
+			if err != nil {
+				t.Fatalf("AIUsageSince: %v", err)
+			}
+			if len(rows) != 1 || rows[0].Purpose != PurposeThemes || rows[0].InputTokens != 1000 || rows[0].OutputTokens != 200 {
+				t.Fatalf("expected one themes request of 1000+200 tokens, got %+v", rows)
+			}
+			if math.Abs(rows[0].Cost-tt.cost) > 1e-9 {
+				t.Errorf("expected cost %v, got %v", tt.cost, rows[0].Cost)
+			}
+		})
+	}
+}
+
+func TestDailyCapRefusesRequests(t *testing.T) {
+	var hits atomic.Int32
+	srv := usageServer(t, "openai", &hits)
+	db := testCache(t)
+	s, err := New(&config.AIConfig{Provider: "openai", BaseURL: srv.URL, DailyTokenCap: 1000}, "key", db)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := s.WhyItMatters(context.Background(), "first", ""); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	_, err = s.WhyItMatters(context.Background(), "second", "")
+	if !errors.Is(err, ErrDailyCap) {
+		t.Fatalf("expected ErrDailyCap once the cap is used up, got %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected the capped request not to be sent, got %d requests", hits.Load())
+	}
+
+	// A job refused by the cap stays queued without using up an attempt.
+	article := cache.Article{ID: "a1", Source: "A", Title: "Rust", Link: "https://example.com/a1"}
+	db.UpsertArticles([]cache.Article{article})
+	db.EnqueueAIJob("a1", cache.AIJobSummarize)
+	if _, err := RunJob(context.Background(), s, db, cache.AIJob{Article: article, Kind: cache.AIJobSummarize}); !errors.Is(err, ErrDailyCap) {
+		t.Fatalf("expected RunJob to hit the cap, got %v", err)
+	}
+	if jobs, _ := db.PendingAIJobs(0); len(jobs) != 1 || jobs[0].Attempts != 0 {
+		t.Errorf("expected the job queued with no attempts, got %+v", jobs)
+	}
+}
+
+func TestFailedUsageWriteStopsCappedRequests(t *testing.T) {
+	var hits atomic.Int32
+	srv := usageServer(t, "openai", &hits)
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := cache.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("opening raw db: %v", err)
+	}
+	defer raw.Close()
+	if _, err := raw.Exec(`CREATE TRIGGER fail_usage BEFORE INSERT ON ai_usage BEGIN SELECT RAISE(FAIL, 'disk full'); END`); err != nil {
+		t.Fatalf("creating trigger: %v", err)
+	}
+
+	s, err := New(&config.AIConfig{Provider: "openai", BaseURL: srv.URL, DailyTokenCap: 100000}, "key", db)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := s.WhyItMatters(context.Background(), "first", ""); err == nil {
+		t.Fatal("expected the failed usage write reported")
+	}
+	_, err = s.WhyItMatters(context.Background(), "second", "")
+	if !errors.Is(err, ErrDailyCap) {
+		t.Fatalf("expected requests refused once usage cannot be recorded, got %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected no request after the failed write, got %d requests", hits.Load())
+	}
+}
+
+func TestMeterCost(t *testing.T) {
+	m := NewMeter(&config.AIConfig{Prices: map[string]config.Price{
+		"gpt-4o-mini": {Input: 1, Output: 2},
+		"llama3.2":    {Input: 0.1, Output: 0.1},
+	}}, nil)
+	tests := []struct {
+		model string
+		want  float64
+	}{
+		{"gpt-4o-mini", 0.005}, // overridden
+		{"claude-haiku-4-5", 0.011},
+		{"llama3.2", 0.0003},
+		{"unknown", 0},
+	}
+	for _, tt := range tests {
+		if got := m.Cost(tt.model, 1000, 2000); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Cost(%q) = %v, want %v", tt.model, got, tt.want)
+		}
+	}
+}
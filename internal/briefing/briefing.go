@@ -3,13 +3,13 @@ package briefing
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 	"unicode"
 
 	"github.com/matheuskafuri/devnews/internal/cache"
-	"github.com/matheuskafuri/devnews/internal/classify"
 )
 
 // Briefing holds briefing data for both V2 (card-based) and V1 (legacy header) modes.
@@ -33,17 +33,36 @@ type Briefing struct {
 type Card struct {
 	Article cache.Article
 	Index   int
+
+	// Score and Reasons explain why the card was chosen; see Rank.
+	Score   float64
+	Reasons []string
 }
 
 // GenerateOpts holds options for the Generate function.
 type GenerateOpts struct {
-	DB            *cache.Cache
-	Since         time.Time
-	BriefSize     int
-	FocusCategory string
+	DB        *cache.Cache
+	Since     time.Time
+	BriefSize int
+
+	// Focus weights categories to rank higher; see RankOpts. Articles in
+	// Exclude categories are left out of the briefing.
+	Focus   map[string]float64
+	Exclude []string
+
+	// SourceWeights scales the score of each source's articles; see RankOpts.
+	SourceWeights map[string]float64
+
+	// Diversity keeps one source or category from taking over; see Select.
+	Diversity Diversity
 }
 
-// Generate creates a V2 briefing by scoring, classifying, and selecting top articles.
+// recentlyReadWindow is how far back read articles count against the
+// novelty of new ones.
+const recentlyReadWindow = 14 * 24 * time.Hour
+
+// Generate creates a V2 briefing by classifying, scoring (see Rank), and
+// selecting the top articles.
 // AI enrichment (WhyItMatters, Themes) is NOT done here — the caller should
 // handle those asynchronously so the opening screen renders immediately.
 func Generate(opts GenerateOpts) (*Briefing, error) {
@@ -60,7 +79,7 @@ func Generate(opts GenerateOpts) (*Briefing, error) {
 	b := &Briefing{
 		DateLabel: time.Now().Format("Jan 2"),
 		Scanned:   len(articles),
-		Focus:     opts.FocusCategory,
+		Focus:     focusLabel(opts.Focus, opts.Exclude),
 	}
 
 	if len(articles) == 0 {
@@ -68,36 +87,39 @@ func Generate(opts GenerateOpts) (*Briefing, error) {
 	}
 
 	// Classify each article
-	for i := range articles {
-		articles[i].Category = string(classify.Classify(articles[i].Title, articles[i].Description))
+	labelArticles(articles, opts.DB)
 
-		// Persist to cache (non-blocking, best-effort)
-		opts.DB.UpdateArticleCategory(articles[i].ID, articles[i].Category)
-	}
-
-	// Filter by focus category if set
-	if opts.FocusCategory != "" {
-		var filtered []cache.Article
+	// Drop excluded categories (by primary label only, so a post that merely
+	// touches on an excluded topic is kept)
+	if len(opts.Exclude) > 0 {
+		var kept []cache.Article
 		for _, a := range articles {
-			if a.Category == opts.FocusCategory {
-				filtered = append(filtered, a)
+			if !slices.Contains(opts.Exclude, a.Category) {
+				kept = append(kept, a)
 			}
 		}
-		articles = filtered
+		articles = kept
 	}
 
-	// Take top N
-	if len(articles) > opts.BriefSize {
-		articles = articles[:opts.BriefSize]
-	}
+	// Score and take top N
+	rankOpts := RankOpts{Focus: opts.Focus, SourceWeights: opts.SourceWeights}
+	rankOpts.RecentlyRead, _ = opts.DB.GetArticles(cache.QueryOpts{
+		Since: time.Now().Add(-recentlyReadWindow),
+		Read:  true,
+	})
+	ranked := Select(Rank(articles, rankOpts), opts.BriefSize, opts.Diversity)
 
-	b.Selected = len(articles)
+	b.Selected = len(ranked)
 
 	// Build cards
-	for i, a := range articles {
+	selected := make([]cache.Article, 0, len(ranked))
+	for i, r := range ranked {
+		selected = append(selected, r.Article)
 		b.Cards = append(b.Cards, Card{
-			Article: a,
+			Article: r.Article,
 			Index:   i + 1,
+			Score:   r.Score,
+			Reasons: r.Reasons,
 		})
 	}
 
@@ -111,7 +133,7 @@ func Generate(opts GenerateOpts) (*Briefing, error) {
 	// TF-IDF fallback for themes (AI themes loaded async by the TUI)
 	if len(b.Themes) == 0 {
 		allArticles, _ := opts.DB.GetArticles(cache.QueryOpts{})
-		trendingText := trending(articles, allArticles)
+		trendingText := trending(selected, allArticles)
 		if trendingText != "" {
 			b.Themes = strings.Split(trendingText, ", ")
 		}
@@ -120,6 +142,33 @@ func Generate(opts GenerateOpts) (*Briefing, error) {
 	return b, nil
 }
 
+// focusLabel describes the focus for display, heaviest categories first:
+// "db:2, security, !ai".
+func focusLabel(focus map[string]float64, exclude []string) string {
+	cats := make([]string, 0, len(focus))
+	for cat := range focus {
+		cats = append(cats, cat)
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		if focus[cats[i]] != focus[cats[j]] {
+			return focus[cats[i]] > focus[cats[j]]
+		}
+		return cats[i] < cats[j]
+	})
+
+	parts := make([]string, 0, len(cats)+len(exclude))
+	for _, cat := range cats {
+		if w := focus[cat]; w != 1 {
+			cat += fmt.Sprintf(":%g", w)
+		}
+		parts = append(parts, cat)
+	}
+	for _, cat := range exclude {
+		parts = append(parts, "!"+cat)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // GenerateLegacy creates a V1-style briefing (for backward compatibility).
 func GenerateLegacy(newArticles []cache.Article, allArticles []cache.Article) Briefing {
 	b := Briefing{
@@ -0,0 +1,78 @@
+package briefing
+
+import (
+	"context"
+
+	"github.com/matheuskafuri/devnews/internal/ai"
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// NeedsWhyItMatters reports whether a card still shows the description
+// excerpt set by Generate rather than AI-written text.
+func NeedsWhyItMatters(c Card) bool {
+	return c.Article.WhyItMatters == "" || c.Article.WhyItMatters == DescriptionExcerpt(c.Article.Description)
+}
+
+// Enrich fills in AI-written WhyItMatters text and themes, asking for all of
+// them concurrently and saving each WhyItMatters to db. It returns when every
+// request has finished or ctx is done, whichever comes first; results that
+// arrive in time are applied and the rest are dropped, so b is never written
+// to after Enrich returns. Failed requests leave the fallback text in place.
+func Enrich(ctx context.Context, b *Briefing, s ai.Summarizer, db *cache.Cache) error {
+	type why struct {
+		index int
+		text  string
+	}
+	// Buffered so requests still running after a timeout never block.
+	whys := make(chan why, len(b.Cards))
+	themes := make(chan []string, 1)
+	pending := 0
+
+	for i, c := range b.Cards {
+		if !NeedsWhyItMatters(c) {
+			continue
+		}
+		pending++
+		go func(i int, art cache.Article) {
+			text, err := s.WhyItMatters(ctx, art.Title, art.Description)
+			if err != nil {
+				text = ""
+			}
+			whys <- why{index: i, text: text}
+		}(i, c.Article)
+	}
+
+	if len(b.Cards) > 0 {
+		summaries := make([]ai.ArticleSummary, len(b.Cards))
+		for i, c := range b.Cards {
+			summaries[i] = ai.ArticleSummary{Title: c.Article.Title, Category: c.Article.Category}
+		}
+		pending++
+		go func() {
+			t, err := s.Themes(ctx, summaries)
+			if err != nil {
+				t = nil
+			}
+			themes <- t
+		}()
+	}
+
+	for pending > 0 {
+		select {
+		case w := <-whys:
+			pending--
+			if w.text != "" {
+				b.Cards[w.index].Article.WhyItMatters = w.text
+				db.UpdateArticleWhyItMatters(b.Cards[w.index].Article.ID, w.text)
+			}
+		case t := <-themes:
+			pending--
+			if len(t) > 0 {
+				b.Themes = t
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package briefing
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/ai"
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// fakeSummarizer answers WhyItMatters after delay, and fails for titles in
+// fail.
+type fakeSummarizer struct {
+	ai.Summarizer
+	delay map[string]time.Duration
+	fail  map[string]bool
+}
+
+func (f fakeSummarizer) WhyItMatters(ctx context.Context, title, _ string) (string, error) {
+	select {
+	case <-time.After(f.delay[title]):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	if f.fail[title] {
+		return "", errors.New("boom")
+	}
+	return "AI: " + title, nil
+}
+
+func (f fakeSummarizer) Themes(context.Context, []ai.ArticleSummary) ([]string, error) {
+	return []string{"ai themes"}, nil
+}
+
+func enrichFixture(t *testing.T) (*Briefing, *cache.Cache) {
+	t.Helper()
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	articles := []cache.Article{
+		{ID: "fast", Title: "fast", Link: "https://example.com/fast", Description: "A description that is long enough."},
+		{ID: "slow", Title: "slow", Link: "https://example.com/slow"},
+		{ID: "broken", Title: "broken", Link: "https://example.com/broken"},
+		{ID: "done", Title: "done", Link: "https://example.com/done", WhyItMatters: "Already written."},
+	}
+	if err := db.UpsertArticles(articles); err != nil {
+		t.Fatalf("UpsertArticles: %v", err)
+	}
+	b := &Briefing{Themes: []string{"keyword"}}
+	for i, a := range articles {
+		b.Cards = append(b.Cards, Card{Article: a, Index: i + 1})
+	}
+	return b, db
+}
+
+func TestEnrich(t *testing.T) {
+	b, db := enrichFixture(t)
+	s := fakeSummarizer{fail: map[string]bool{"broken": true}}
+
+	if err := Enrich(context.Background(), b, s, db); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	want := []string{"AI: fast", "AI: slow", "", "Already written."}
+	for i, c := range b.Cards {
+		if c.Article.WhyItMatters != want[i] {
+			t.Errorf("card %d: WhyItMatters = %q, want %q", i, c.Article.WhyItMatters, want[i])
+		}
+	}
+	if len(b.Themes) != 1 || b.Themes[0] != "ai themes" {
+		t.Errorf("expected AI themes, got %v", b.Themes)
+	}
+
+	stored, err := db.GetArticles(cache.QueryOpts{Search: "fast"})
+	if err != nil || len(stored) != 1 || stored[0].WhyItMatters != "AI: fast" {
+		t.Errorf("expected WhyItMatters saved to the cache, got %+v, %v", stored, err)
+	}
+}
+
+func TestEnrichTimeout(t *testing.T) {
+	b, db := enrichFixture(t)
+	s := fakeSummarizer{delay: map[string]time.Duration{"slow": time.Minute}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := Enrich(ctx, b, s, db); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if b.Cards[0].Article.WhyItMatters != "AI: fast" {
+		t.Errorf("expected results that arrived in time to be applied, got %q", b.Cards[0].Article.WhyItMatters)
+	}
+	if b.Cards[1].Article.WhyItMatters != "" {
+		t.Errorf("expected the slow card untouched, got %q", b.Cards[1].Article.WhyItMatters)
+	}
+}
@@ -0,0 +1,138 @@
+package briefing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/ai"
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/classify"
+)
+
+// ClassifyTimeout bounds a Classify call made after a refresh.
+const ClassifyTimeout = time.Minute
+
+// classifyWindow is how far back Classify looks for articles to send: the
+// default briefing window.
+const classifyWindow = 24 * time.Hour
+
+// Classify sends the articles published in the last day that the AI has not
+// been asked about to c, and saves the categories it assigns. Articles it
+// answered but could not place, or all that are left when c fails
+// permanently, are labelled by keyword and marked so they are not sent
+// again. Articles left unanswered by a transient failure stay unmarked for
+// the next run. It is meant to run in the background after a refresh;
+// Generate only reads the saved labels.
+func Classify(ctx context.Context, db *cache.Cache, c ai.Classifier) error {
+	articles, err := db.GetArticlesSince(time.Now().Add(-classifyWindow))
+	if err != nil {
+		return err
+	}
+	names, known := categoryNames()
+
+	var pending []ai.ClassifyInput
+	byID := make(map[string]*cache.Article)
+	for i := range articles {
+		a := &articles[i]
+		if hasAILabels(*a, known) || a.CategorySource == cache.CategorySourceAIFallback {
+			continue
+		}
+		pending = append(pending, ai.ClassifyInput{ID: a.ID, Title: a.Title, Description: a.Description})
+		byID[a.ID] = a
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Partial results are still used.
+	aiCategories, err := c.Classify(ctx, pending, names)
+	giveUp := err != nil && ai.Permanent(err)
+	for _, p := range pending {
+		a := byID[p.ID]
+		cats, answered := aiCategories[a.ID]
+		var saveErr error
+		switch {
+		case len(cats) > 0:
+			saveErr = db.SetArticleLabels(a.ID, rankLabels(cats), cache.CategorySourceAI)
+		case answered || giveUp:
+			saveErr = db.SetArticleLabels(a.ID, keywordLabels(*a), cache.CategorySourceAIFallback)
+		}
+		if saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+	}
+	return err
+}
+
+// labelArticles assigns category labels to articles and saves them to db.
+// Labels the AI assigned are kept as long as their categories still exist;
+// the rest are classified by keyword.
+func labelArticles(articles []cache.Article, db *cache.Cache) {
+	_, known := categoryNames()
+	for i := range articles {
+		a := &articles[i]
+		if hasAILabels(*a, known) {
+			continue
+		}
+		source := cache.CategorySourceKeyword
+		if a.CategorySource == cache.CategorySourceAIFallback {
+			source = a.CategorySource
+		}
+		a.Labels = keywordLabels(*a)
+		a.Category = a.Labels[0].Category
+		a.CategorySource = source
+
+		// Persist to cache (best-effort)
+		db.SetArticleLabels(a.ID, a.Labels, source)
+	}
+}
+
+// categoryNames returns the names of every category, and the same as a set.
+func categoryNames() ([]string, map[string]bool) {
+	categories := classify.AllCategories()
+	names := make([]string, len(categories))
+	known := make(map[string]bool, len(categories))
+	for i, cat := range categories {
+		names[i] = string(cat)
+		known[names[i]] = true
+	}
+	return names, known
+}
+
+func keywordLabels(a cache.Article) []cache.Label {
+	labels := classify.ClassifyAll(a.Title, a.Description)
+	out := make([]cache.Label, len(labels))
+	for i, l := range labels {
+		out[i] = cache.Label{Category: string(l.Category), Score: l.Score}
+	}
+	return out
+}
+
+// hasAILabels reports whether a was classified by the AI into categories
+// that are all still in use.
+func hasAILabels(a cache.Article, known map[string]bool) bool {
+	if a.CategorySource != cache.CategorySourceAI || len(a.Labels) == 0 {
+		return false
+	}
+	for _, l := range a.Labels {
+		if !known[l.Category] {
+			return false
+		}
+	}
+	return true
+}
+
+// rankLabels turns categories ordered best first into labels whose scores
+// fall off with rank (1, 1/2, 1/3) and sum to 1.
+func rankLabels(categories []string) []cache.Label {
+	total := 0.0
+	for i := range categories {
+		total += 1 / float64(i+1)
+	}
+	labels := make([]cache.Label, len(categories))
+	for i, cat := range categories {
+		labels[i] = cache.Label{Category: cat, Score: 1 / float64(i+1) / total}
+	}
+	return labels
+}
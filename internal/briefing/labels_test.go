@@ -0,0 +1,136 @@
+package briefing
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/ai"
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// fakeClassifier answers with fixed categories per article id and records
+// which articles it was asked about. When err is set, only the articles with
+// an answer count as answered, as if the batches after them failed.
+type fakeClassifier struct {
+	answers map[string][]string
+	err     error
+	asked   []string
+}
+
+func (f *fakeClassifier) Classify(_ context.Context, articles []ai.ClassifyInput, _ []string) (map[string][]string, error) {
+	results := make(map[string][]string)
+	for _, a := range articles {
+		f.asked = append(f.asked, a.ID)
+		if cats, ok := f.answers[a.ID]; ok || f.err == nil {
+			results[a.ID] = cats
+		}
+	}
+	return results, f.err
+}
+
+func TestClassify(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	if err := db.UpsertArticles([]cache.Article{
+		{ID: "model", Source: "A", Title: "A new data model for billing", Link: "https://example.com/1", Published: now},
+		{ID: "postgres", Source: "B", Title: "Postgres vacuum tuning", Link: "https://example.com/2", Published: now},
+	}); err != nil {
+		t.Fatalf("UpsertArticles: %v", err)
+	}
+	load := func() map[string]cache.Article {
+		articles, err := db.GetArticlesSince(now.Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("GetArticlesSince: %v", err)
+		}
+		byID := make(map[string]cache.Article)
+		for _, a := range articles {
+			byID[a.ID] = a
+		}
+		return byID
+	}
+
+	// The AI places one article and fails before the other, which is left
+	// for the next run.
+	c := &fakeClassifier{answers: map[string][]string{"model": {"Platform", "Databases"}}, err: errors.New("boom")}
+	if err := Classify(context.Background(), db, c); err == nil {
+		t.Error("expected the classifier's error")
+	}
+
+	got := load()
+	if a := got["model"]; a.Category != "Platform" || a.CategorySource != cache.CategorySourceAI || len(a.Labels) != 2 || a.Labels[0].Score <= a.Labels[1].Score {
+		t.Errorf("expected ranked AI labels, got %q %q %+v", a.Category, a.CategorySource, a.Labels)
+	}
+	if a := got["postgres"]; a.CategorySource != "" {
+		t.Errorf("expected the unanswered article left unlabelled, got %q", a.CategorySource)
+	}
+
+	// Retried, the AI answers but cannot place it; it falls back to keywords.
+	c = &fakeClassifier{}
+	if err := Classify(context.Background(), db, c); err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if len(c.asked) != 1 || c.asked[0] != "postgres" {
+		t.Errorf("expected the unanswered article retried, got %v", c.asked)
+	}
+	if a := load()["postgres"]; a.Category != "Databases" || a.CategorySource != cache.CategorySourceAIFallback {
+		t.Errorf("expected keyword fallback, got %q %q", a.Category, a.CategorySource)
+	}
+
+	// Articles the AI was already asked about are not sent again; only new
+	// ones are.
+	db.UpsertArticles([]cache.Article{
+		{ID: "k8s", Source: "C", Title: "Kubernetes autoscaling", Link: "https://example.com/3", Published: now},
+	})
+	c = &fakeClassifier{}
+	if err := Classify(context.Background(), db, c); err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if len(c.asked) != 1 || c.asked[0] != "k8s" {
+		t.Errorf("expected only the new article to be sent, got %v", c.asked)
+	}
+	c = &fakeClassifier{}
+	Classify(context.Background(), db, c)
+	if len(c.asked) != 0 {
+		t.Errorf("expected nothing sent on a second pass, got %v", c.asked)
+	}
+
+	// Generate's labelling keeps AI labels and the fallback marker.
+	articles, _ := db.GetArticlesSince(now.Add(-time.Hour))
+	labelArticles(articles, db)
+	got = load()
+	if a := got["model"]; a.Category != "Platform" || a.CategorySource != cache.CategorySourceAI {
+		t.Errorf("expected cached AI labels to be kept, got %q %q", a.Category, a.CategorySource)
+	}
+	if a := got["postgres"]; a.CategorySource != cache.CategorySourceAIFallback {
+		t.Errorf("expected the fallback marker to be kept, got %q", a.CategorySource)
+	}
+}
+
+func TestClassifyPermanentErrorFallsBack(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	db.UpsertArticles([]cache.Article{
+		{ID: "postgres", Source: "B", Title: "Postgres vacuum tuning", Link: "https://example.com/2", Published: now},
+	})
+	c := &fakeClassifier{err: &ai.APIError{Provider: "claude", StatusCode: 400}}
+	if err := Classify(context.Background(), db, c); err == nil {
+		t.Error("expected the classifier's error")
+	}
+	articles, _ := db.GetArticlesSince(now.Add(-time.Hour))
+	if len(articles) != 1 || articles[0].CategorySource != cache.CategorySourceAIFallback {
+		t.Errorf("expected keyword fallback after a permanent error, got %+v", articles)
+	}
+}
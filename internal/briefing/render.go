@@ -0,0 +1,210 @@
+package briefing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format is an output format for Render.
+type Format string
+
+const (
+	Text     Format = "text"
+	ANSI     Format = "ansi"
+	Markdown Format = "markdown"
+	JSON     Format = "json"
+)
+
+// ParseFormat resolves a format name, accepting "md" for Markdown.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "text", "plain":
+		return Text, nil
+	case "ansi", "color":
+		return ANSI, nil
+	case "markdown", "md":
+		return Markdown, nil
+	case "json":
+		return JSON, nil
+	}
+	return "", fmt.Errorf("unknown format %q (valid: text, ansi, markdown, json)", s)
+}
+
+// Render writes the briefing to w for reading outside the TUI.
+func Render(w io.Writer, b *Briefing, format Format) error {
+	switch format {
+	case Text:
+		return renderText(w, b, plain)
+	case ANSI:
+		return renderText(w, b, ansi)
+	case Markdown:
+		return renderMarkdown(w, b)
+	case JSON:
+		return renderJSON(w, b)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// palette styles the text renderer; plain leaves everything as is.
+type palette struct {
+	title, accent, dim func(string) string
+}
+
+func sgr(code string) func(string) string {
+	return func(s string) string { return "\x1b[" + code + "m" + s + "\x1b[0m" }
+}
+
+var (
+	plain = palette{title: noStyle, accent: noStyle, dim: noStyle}
+	ansi  = palette{title: sgr("1"), accent: sgr("36"), dim: sgr("2")}
+)
+
+func noStyle(s string) string { return s }
+
+func renderText(w io.Writer, b *Briefing, p palette) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n", p.title("devnews briefing — "+b.DateLabel))
+	fmt.Fprintf(&sb, "%s\n", p.dim(summaryLine(b)))
+	if len(b.Themes) > 0 {
+		fmt.Fprintf(&sb, "%s %s\n", p.accent("Themes:"), strings.Join(b.Themes, ", "))
+	}
+	if len(b.Cards) == 0 {
+		sb.WriteString("\nNothing new.\n")
+	}
+
+	for _, c := range b.Cards {
+		a := c.Article
+		fmt.Fprintf(&sb, "\n%s %s\n", p.accent(fmt.Sprintf("%d.", c.Index)), p.title(oneLine(a.Title)))
+		fmt.Fprintf(&sb, "   %s\n", p.dim(cardMeta(c)))
+		if a.WhyItMatters != "" {
+			fmt.Fprintf(&sb, "   %s\n", oneLine(a.WhyItMatters))
+		}
+		if len(c.Reasons) > 0 {
+			fmt.Fprintf(&sb, "   %s\n", p.dim("Picked: "+ReasonText(c.Reasons)))
+		}
+		fmt.Fprintf(&sb, "   %s\n", p.dim(a.Link))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func renderMarkdown(w io.Writer, b *Briefing) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# devnews briefing — %s\n\n", b.DateLabel)
+	fmt.Fprintf(&sb, "*%s*\n", summaryLine(b))
+	if len(b.Themes) > 0 {
+		fmt.Fprintf(&sb, "\n**Themes:** %s\n", strings.Join(b.Themes, ", "))
+	}
+	if len(b.Cards) == 0 {
+		sb.WriteString("\nNothing new.\n")
+	}
+
+	for _, c := range b.Cards {
+		a := c.Article
+		title := strings.NewReplacer(`[`, `\[`, `]`, `\]`).Replace(oneLine(a.Title))
+		fmt.Fprintf(&sb, "\n## %d. [%s](%s)\n\n", c.Index, title, a.Link)
+		fmt.Fprintf(&sb, "*%s*\n", cardMeta(c))
+		if a.WhyItMatters != "" {
+			fmt.Fprintf(&sb, "\n%s\n", oneLine(a.WhyItMatters))
+		}
+		if len(c.Reasons) > 0 {
+			fmt.Fprintf(&sb, "\n*Picked: %s*\n", ReasonText(c.Reasons))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type jsonCard struct {
+	Index        int         `json:"index"`
+	ID           string      `json:"id"`
+	Title        string      `json:"title"`
+	Source       string      `json:"source"`
+	Link         string      `json:"link"`
+	Published    time.Time   `json:"published"`
+	Category     string      `json:"category,omitempty"`
+	Labels       []jsonLabel `json:"labels,omitempty"`
+	WhyItMatters string      `json:"why_it_matters,omitempty"`
+	Summary      string      `json:"summary,omitempty"`
+	Score        float64     `json:"score"`
+	Reasons      []string    `json:"reasons"`
+}
+
+type jsonLabel struct {
+	Category string  `json:"category"`
+	Score    float64 `json:"score"`
+}
+
+type jsonBriefing struct {
+	Date     string     `json:"date"`
+	Focus    string     `json:"focus,omitempty"`
+	Scanned  int        `json:"scanned"`
+	Selected int        `json:"selected"`
+	Themes   []string   `json:"themes"`
+	Cards    []jsonCard `json:"cards"`
+}
+
+func renderJSON(w io.Writer, b *Briefing) error {
+	out := jsonBriefing{
+		Date:     b.DateLabel,
+		Focus:    b.Focus,
+		Scanned:  b.Scanned,
+		Selected: b.Selected,
+		Themes:   append([]string{}, b.Themes...),
+		Cards:    []jsonCard{},
+	}
+	for _, c := range b.Cards {
+		a := c.Article
+		var labels []jsonLabel
+		for _, l := range a.Labels {
+			labels = append(labels, jsonLabel{Category: l.Category, Score: l.Score})
+		}
+		out.Cards = append(out.Cards, jsonCard{
+			Index:        c.Index,
+			ID:           a.ID,
+			Title:        a.Title,
+			Source:       a.Source,
+			Link:         a.Link,
+			Published:    a.Published,
+			Category:     a.Category,
+			Labels:       labels,
+			WhyItMatters: a.WhyItMatters,
+			Summary:      a.Summary,
+			Score:        c.Score,
+			Reasons:      append([]string{}, c.Reasons...),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func summaryLine(b *Briefing) string {
+	line := fmt.Sprintf("%d scanned · %d selected", b.Scanned, b.Selected)
+	if b.Focus != "" {
+		line += " · focus: " + b.Focus
+	}
+	return line
+}
+
+func cardMeta(c Card) string {
+	meta := []string{c.Article.Source, c.Article.Published.Format("Jan 2 15:04")}
+	if c.Article.Category != "" {
+		meta = append(meta, c.Article.Category)
+	}
+	for _, l := range c.Article.Labels {
+		if l.Category != c.Article.Category {
+			meta = append(meta, l.Category)
+		}
+	}
+	return strings.Join(meta, " · ")
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
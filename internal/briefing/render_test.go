@@ -0,0 +1,127 @@
+package briefing
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+func sampleBriefing() *Briefing {
+	pub := time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)
+	return &Briefing{
+		DateLabel: "Mar 4",
+		Scanned:   12,
+		Selected:  2,
+		Focus:     "db",
+		Themes:    []string{"postgres", "replication"},
+		Cards: []Card{
+			{Index: 1, Article: cache.Article{ID: "a1", Title: "Scaling [Postgres]", Source: "Stripe", Link: "https://stripe.com/a", Published: pub, Category: "db", Labels: []cache.Label{{Category: "db", Score: 0.7}, {Category: "infra", Score: 0.3}}, WhyItMatters: "Fewer\nfailovers."}, Score: 1.5, Reasons: []string{"covered by 2 other sources", "matches your focus (db)"}},
+			{Index: 2, Article: cache.Article{ID: "a2", Title: "Logical replication", Source: "GitHub", Link: "https://github.blog/b", Published: pub}},
+		},
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, sampleBriefing(), Text); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"devnews briefing — Mar 4",
+		"12 scanned · 2 selected · focus: db",
+		"Themes: postgres, replication",
+		"1. Scaling [Postgres]",
+		"Stripe · Mar 4 09:30 · db · infra",
+		"Fewer failovers.",
+		"Picked: covered by 2 other sources · matches your focus (db)",
+		"https://github.blog/b",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in text output:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\x1b[") {
+		t.Error("expected no escape codes in plain text")
+	}
+}
+
+func TestRenderANSI(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, sampleBriefing(), ANSI); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[1mScaling [Postgres]\x1b[0m") {
+		t.Errorf("expected bold titles, got:\n%q", buf.String())
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, sampleBriefing(), Markdown); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"# devnews briefing — Mar 4",
+		"**Themes:** postgres, replication",
+		`## 1. [Scaling \[Postgres\]](https://stripe.com/a)`,
+		"*GitHub · Mar 4 09:30*",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in markdown output:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, sampleBriefing(), JSON); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	var out struct {
+		Scanned int      `json:"scanned"`
+		Themes  []string `json:"themes"`
+		Cards   []struct {
+			Index        int      `json:"index"`
+			Title        string   `json:"title"`
+			WhyItMatters string   `json:"why_it_matters"`
+			Score        float64  `json:"score"`
+			Reasons      []string `json:"reasons"`
+			Labels       []struct {
+				Category string  `json:"category"`
+				Score    float64 `json:"score"`
+			} `json:"labels"`
+		} `json:"cards"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if out.Scanned != 12 || len(out.Themes) != 2 || len(out.Cards) != 2 {
+		t.Errorf("unexpected JSON: %+v", out)
+	}
+	if out.Cards[0].Index != 1 || out.Cards[0].WhyItMatters != "Fewer\nfailovers." {
+		t.Errorf("unexpected first card: %+v", out.Cards[0])
+	}
+	if out.Cards[0].Score != 1.5 || len(out.Cards[0].Reasons) != 2 || out.Cards[1].Reasons == nil {
+		t.Errorf("expected score and reasons on every card: %+v", out.Cards)
+	}
+	if labels := out.Cards[0].Labels; len(labels) != 2 || labels[1].Category != "infra" || labels[1].Score != 0.3 {
+		t.Errorf("expected ranked labels, got %+v", labels)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"text": Text, "ANSI": ANSI, "md": Markdown, "json": JSON} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("html"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,239 @@
+package briefing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// Scoring weights. Recency, overlap and novelty are normalized to [0, 1]
+// before weighting, so these set how much one signal can outweigh another: a
+// fresh post beats an older one covered elsewhere, which beats a focus match
+// on an old post. The focus signal is the focus weight itself, which is not
+// capped, so a weight above 1 lets a focus match outweigh the other signals.
+const (
+	weightRecency = 1.0
+	weightOverlap = 0.8
+	weightFocus   = 0.6
+	weightNovelty = 0.4
+
+	// recencyHalfLife is the age at which the recency signal halves.
+	recencyHalfLife = 24 * time.Hour
+
+	// readPenalty scales down articles the user has already opened.
+	readPenalty = 0.25
+
+	// overlapSaturation is the number of other sources covering a topic at
+	// which the overlap signal is maxed out.
+	overlapSaturation = 3
+
+	// minSharedTokens is how many title keywords two articles must share to
+	// count as the same topic.
+	minSharedTokens = 2
+
+	// secondaryFocus scales the focus signal when a focused category is only
+	// one of an article's secondary labels.
+	secondaryFocus = 0.5
+
+	// similarToReadThreshold is the similarity to a read article above which
+	// a card is called out as covering familiar ground.
+	similarToReadThreshold = 0.5
+)
+
+// RankOpts configures Rank.
+type RankOpts struct {
+	// Now is the reference time for recency. Zero uses time.Now().
+	Now time.Time
+
+	// Focus weights categories of interest. An article whose primary category
+	// is focused gains the focus signal scaled by its weight, so a weight of 2
+	// counts twice as much as 1; a focused secondary label counts half.
+	Focus map[string]float64
+
+	// SourceWeights multiplies the score of every article from a source.
+	// Sources without an entry have weight 1.
+	SourceWeights map[string]float64
+
+	// RecentlyRead are articles the user read lately. Articles on the same
+	// topic are less novel.
+	RecentlyRead []cache.Article
+}
+
+// Ranked is an article with its score and the reasons behind it.
+type Ranked struct {
+	Article cache.Article
+	Score   float64
+	Reasons []string
+}
+
+// Rank scores articles and returns them best first. Ties keep the input
+// order, so callers passing newest-first articles get newest-first ties.
+//
+// The score adds up four signals: recency (exponential decay with a one-day
+// half-life), cross-source overlap (how many other sources posted about the
+// same topic), focus match on primary and secondary labels, and novelty
+// against recently read articles. The sum is then multiplied by the source
+// weight, and by readPenalty when the article has been read.
+func Rank(articles []cache.Article, opts RankOpts) []Ranked {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	tokens := make([]map[string]bool, len(articles))
+	for i, a := range articles {
+		tokens[i] = tokenSet(a.Title)
+	}
+	readTokens := make([]map[string]bool, len(opts.RecentlyRead))
+	for i, a := range opts.RecentlyRead {
+		readTokens[i] = tokenSet(a.Title)
+	}
+
+	ranked := make([]Ranked, len(articles))
+	for i, a := range articles {
+		var reasons []string
+
+		age := now.Sub(a.Published)
+		if age < 0 {
+			age = 0
+		}
+		recency := math.Pow(0.5, float64(age)/float64(recencyHalfLife))
+		if age < 6*time.Hour {
+			reasons = append(reasons, fmt.Sprintf("fresh (%s old)", formatAge(age)))
+		}
+
+		others := otherSources(i, articles, tokens)
+		overlap := math.Min(float64(others)/overlapSaturation, 1)
+		if others > 0 {
+			reasons = append(reasons, fmt.Sprintf("covered by %d other source%s", others, plural(others)))
+		}
+
+		focus, focusCategory := focusMatch(a, opts.Focus)
+		switch focusCategory {
+		case "":
+		case a.Category:
+			reasons = append(reasons, "matches your focus ("+focusCategory+")")
+		default:
+			reasons = append(reasons, "touches on your focus ("+focusCategory+")")
+		}
+
+		novelty := 1.0
+		if len(readTokens) > 0 {
+			similar := 0.0
+			for j, rt := range readTokens {
+				if opts.RecentlyRead[j].ID == a.ID {
+					continue // the read penalty covers this
+				}
+				similar = math.Max(similar, similarity(tokens[i], rt))
+			}
+			novelty = 1 - similar
+			if similar >= similarToReadThreshold {
+				reasons = append(reasons, "similar to something you read")
+			}
+		}
+
+		score := weightRecency*recency + weightOverlap*overlap + weightFocus*focus + weightNovelty*novelty
+
+		if w, ok := opts.SourceWeights[a.Source]; ok && w != 1 {
+			score *= w
+			if w > 1 {
+				reasons = append(reasons, fmt.Sprintf("boosted source (×%g)", w))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("down-weighted source (×%g)", w))
+			}
+		}
+		if a.Read {
+			score *= readPenalty
+			reasons = append(reasons, "already read")
+		}
+
+		ranked[i] = Ranked{Article: a, Score: score, Reasons: reasons}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// focusMatch returns the focus signal for an article and the focused
+// category behind it, or "" when none of its labels are focused. The primary
+// category counts in full, secondary labels by secondaryFocus.
+func focusMatch(a cache.Article, focus map[string]float64) (float64, string) {
+	best, category := 0.0, ""
+	if w := focus[a.Category]; w > 0 {
+		best, category = w, a.Category
+	}
+	for _, l := range a.Labels {
+		if w := focus[l.Category] * secondaryFocus; l.Category != a.Category && w > best {
+			best, category = w, l.Category
+		}
+	}
+	return best, category
+}
+
+// otherSources counts the distinct sources, other than article i's own, that
+// have an article sharing its topic.
+func otherSources(i int, articles []cache.Article, tokens []map[string]bool) int {
+	seen := map[string]bool{}
+	for j, b := range articles {
+		if j == i || b.Source == articles[i].Source || seen[b.Source] {
+			continue
+		}
+		if sharedTokens(tokens[i], tokens[j]) >= minSharedTokens {
+			seen[b.Source] = true
+		}
+	}
+	return len(seen)
+}
+
+func tokenSet(title string) map[string]bool {
+	set := map[string]bool{}
+	for _, t := range tokenize(title) {
+		set[t] = true
+	}
+	return set
+}
+
+func sharedTokens(a, b map[string]bool) int {
+	n := 0
+	for t := range a {
+		if b[t] {
+			n++
+		}
+	}
+	return n
+}
+
+// similarity is the overlap coefficient of two token sets: shared tokens
+// over the size of the smaller set.
+func similarity(a, b map[string]bool) float64 {
+	smaller := min(len(a), len(b))
+	if smaller == 0 {
+		return 0
+	}
+	return float64(sharedTokens(a, b)) / float64(smaller)
+}
+
+func formatAge(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// ReasonText joins a card's reasons for display.
+func ReasonText(reasons []string) string {
+	return strings.Join(reasons, " · ")
+}
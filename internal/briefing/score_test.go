@@ -0,0 +1,131 @@
+package briefing
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+var rankNow = time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+func rankArticle(id, source, title string, age time.Duration) cache.Article {
+	return cache.Article{ID: id, Source: source, Title: title, Published: rankNow.Add(-age)}
+}
+
+func rankedIDs(ranked []Ranked) []string {
+	ids := make([]string, len(ranked))
+	for i, r := range ranked {
+		ids[i] = r.Article.ID
+	}
+	return ids
+}
+
+func hasReason(r Ranked, prefix string) bool {
+	for _, reason := range r.Reasons {
+		if strings.HasPrefix(reason, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRankRecency(t *testing.T) {
+	articles := []cache.Article{
+		rankArticle("old", "A", "Kernel scheduler internals", 48*time.Hour),
+		rankArticle("new", "B", "Compiler pipeline rewrite", time.Hour),
+	}
+	got := rankedIDs(Rank(articles, RankOpts{Now: rankNow}))
+	if got[0] != "new" {
+		t.Errorf("expected newer article first, got %v", got)
+	}
+}
+
+func TestRankCrossSourceOverlap(t *testing.T) {
+	articles := []cache.Article{
+		rankArticle("solo", "A", "Compiler pipeline rewrite", time.Hour),
+		rankArticle("hot1", "B", "Postgres replication outage postmortem", 8*time.Hour),
+		rankArticle("hot2", "C", "Lessons from the Postgres replication outage", 9*time.Hour),
+		rankArticle("hot3", "D", "Postgres replication outage explained", 10*time.Hour),
+	}
+	ranked := Rank(articles, RankOpts{Now: rankNow})
+	if ranked[0].Article.ID != "hot1" {
+		t.Errorf("expected widely covered story first, got %v", rankedIDs(ranked))
+	}
+	if !hasReason(ranked[0], "covered by 2 other sources") {
+		t.Errorf("expected overlap reason, got %v", ranked[0].Reasons)
+	}
+}
+
+func TestRankFocusAndSourceWeight(t *testing.T) {
+	articles := []cache.Article{
+		rankArticle("a", "A", "Kernel scheduler internals", time.Hour),
+		rankArticle("b", "B", "Compiler pipeline rewrite", time.Hour),
+	}
+	articles[1].Category = "db"
+
+	ranked := Rank(articles, RankOpts{Now: rankNow, Focus: map[string]float64{"db": 1}})
+	if ranked[0].Article.ID != "b" || !hasReason(ranked[0], "matches your focus") {
+		t.Errorf("expected focused article first with a reason, got %v %v", rankedIDs(ranked), ranked[0].Reasons)
+	}
+
+	ranked = Rank(articles, RankOpts{Now: rankNow, SourceWeights: map[string]float64{"A": 2}})
+	if ranked[0].Article.ID != "a" || !hasReason(ranked[0], "boosted source") {
+		t.Errorf("expected boosted source first with a reason, got %v %v", rankedIDs(ranked), ranked[0].Reasons)
+	}
+}
+
+func TestRankReadAndNovelty(t *testing.T) {
+	articles := []cache.Article{
+		rankArticle("read", "A", "Kernel scheduler internals", time.Hour),
+		rankArticle("familiar", "B", "Rust async runtime deep dive", time.Hour),
+		rankArticle("novel", "C", "Compiler pipeline rewrite", time.Hour),
+	}
+	articles[0].Read = true
+	recentlyRead := []cache.Article{
+		articles[0],
+		rankArticle("earlier", "D", "Rust async runtime benchmarks", 72*time.Hour),
+	}
+
+	ranked := Rank(articles, RankOpts{Now: rankNow, RecentlyRead: recentlyRead})
+	got := rankedIDs(ranked)
+	if got[0] != "novel" || got[1] != "familiar" || got[2] != "read" {
+		t.Errorf("expected novel, familiar, read; got %v", got)
+	}
+	if !hasReason(ranked[1], "similar to something you read") {
+		t.Errorf("expected similarity reason, got %v", ranked[1].Reasons)
+	}
+	if !hasReason(ranked[2], "already read") || hasReason(ranked[2], "similar to") {
+		t.Errorf("expected read reason without self-similarity, got %v", ranked[2].Reasons)
+	}
+}
+
+func TestRankTiesKeepInputOrder(t *testing.T) {
+	articles := []cache.Article{
+		rankArticle("first", "A", "Kernel scheduler internals", time.Hour),
+		rankArticle("second", "B", "Compiler pipeline rewrite", time.Hour),
+	}
+	got := rankedIDs(Rank(articles, RankOpts{Now: rankNow}))
+	if got[0] != "first" || got[1] != "second" {
+		t.Errorf("expected input order for ties, got %v", got)
+	}
+}
+
+func TestRankSecondaryFocus(t *testing.T) {
+	articles := []cache.Article{
+		rankArticle("other", "A", "Kernel scheduler internals", time.Hour),
+		rankArticle("secondary", "B", "Postgres replication on Kubernetes", time.Hour),
+	}
+	articles[0].Category = "Platform"
+	articles[1].Category = "Databases"
+	articles[1].Labels = []cache.Label{{Category: "Databases", Score: 0.6}, {Category: "Infrastructure", Score: 0.4}}
+
+	ranked := Rank(articles, RankOpts{Now: rankNow, Focus: map[string]float64{"Infrastructure": 1}})
+	if ranked[0].Article.ID != "secondary" || !hasReason(ranked[0], "touches on your focus (Infrastructure)") {
+		t.Errorf("expected the secondary focus match first, got %v %v", rankedIDs(ranked), ranked[0].Reasons)
+	}
+	if len(ranked[1].Reasons) != 1 {
+		t.Errorf("expected only the freshness reason on the unfocused article, got %v", ranked[1].Reasons)
+	}
+}
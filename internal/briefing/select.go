@@ -0,0 +1,78 @@
+package briefing
+
+// Diversity limits how much of a briefing one source or category may take.
+// Zero values mean no limit.
+type Diversity struct {
+	MaxPerSource   int // most cards from any one source
+	MaxPerCategory int // most cards in any one category
+	MinCategories  int // fewest distinct categories, when enough are available
+}
+
+// Select picks n articles from ranked, best first, following the diversity
+// rules. The best article of each of the top MinCategories categories is
+// picked first, then the rest are filled in rank order within the per-source
+// and per-category caps. If the caps leave the briefing short of n, they are
+// relaxed and the best remaining articles fill it up. The result keeps rank
+// order.
+func Select(ranked []Ranked, n int, d Diversity) []Ranked {
+	if n <= 0 {
+		return nil
+	}
+	if len(ranked) <= n {
+		return ranked
+	}
+
+	picked := make([]bool, len(ranked))
+	perSource := map[string]int{}
+	perCategory := map[string]int{}
+	count := 0
+	pick := func(i int) {
+		picked[i] = true
+		perSource[ranked[i].Article.Source]++
+		if c := ranked[i].Article.Category; c != "" {
+			perCategory[c]++
+		}
+		count++
+	}
+	fits := func(i int) bool {
+		a := ranked[i].Article
+		if d.MaxPerSource > 0 && perSource[a.Source] >= d.MaxPerSource {
+			return false
+		}
+		if d.MaxPerCategory > 0 && a.Category != "" && perCategory[a.Category] >= d.MaxPerCategory {
+			return false
+		}
+		return true
+	}
+
+	// Reserve a slot for the best article of each category until enough
+	// categories are covered.
+	for i := range ranked {
+		if len(perCategory) >= d.MinCategories || count == n {
+			break
+		}
+		if c := ranked[i].Article.Category; c != "" && perCategory[c] == 0 && fits(i) {
+			pick(i)
+		}
+	}
+
+	// Fill in rank order within the caps, then without them.
+	for _, capped := range []bool{true, false} {
+		for i := range ranked {
+			if count == n {
+				break
+			}
+			if !picked[i] && (!capped || fits(i)) {
+				pick(i)
+			}
+		}
+	}
+
+	out := make([]Ranked, 0, n)
+	for i, r := range ranked {
+		if picked[i] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
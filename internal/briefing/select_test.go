@@ -0,0 +1,188 @@
+package briefing
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// synthetic builds ranked articles from "source/category" pairs, best first.
+func synthetic(specs ...string) []Ranked {
+	ranked := make([]Ranked, len(specs))
+	for i, spec := range specs {
+		var source, category string
+		fmt.Sscanf(spec, "%1s/%s", &source, &category)
+		ranked[i] = Ranked{
+			Article: cache.Article{ID: fmt.Sprintf("%d", i), Source: source, Category: category},
+			Score:   float64(len(specs) - i),
+		}
+	}
+	return ranked
+}
+
+func selectedIDs(ranked []Ranked) []string {
+	ids := []string{}
+	for _, r := range ranked {
+		ids = append(ids, r.Article.ID)
+	}
+	return ids
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranked []Ranked
+		n      int
+		rules  Diversity
+		want   []string
+	}{
+		{
+			name:   "no rules takes the top n",
+			ranked: synthetic("A/ai", "A/ai", "A/ai", "B/db", "C/infra"),
+			n:      3,
+			want:   []string{"0", "1", "2"},
+		},
+		{
+			name:   "max per source",
+			ranked: synthetic("A/ai", "A/ai", "A/ai", "B/db", "C/infra"),
+			n:      3,
+			rules:  Diversity{MaxPerSource: 2},
+			want:   []string{"0", "1", "3"},
+		},
+		{
+			name:   "max per category",
+			ranked: synthetic("A/ai", "B/ai", "C/ai", "D/db", "E/infra"),
+			n:      3,
+			rules:  Diversity{MaxPerCategory: 1},
+			want:   []string{"0", "3", "4"},
+		},
+		{
+			name:   "min categories reserves the best of the next category",
+			ranked: synthetic("A/ai", "B/ai", "C/ai", "D/ai", "E/db", "F/infra"),
+			n:      3,
+			rules:  Diversity{MinCategories: 3},
+			want:   []string{"0", "4", "5"},
+		},
+		{
+			name:   "min categories already met",
+			ranked: synthetic("A/ai", "B/db", "C/ai", "D/infra"),
+			n:      3,
+			rules:  Diversity{MinCategories: 2},
+			want:   []string{"0", "1", "2"},
+		},
+		{
+			name:   "caps are relaxed to fill the briefing",
+			ranked: synthetic("A/ai", "A/ai", "A/ai", "B/db", "A/ai"),
+			n:      4,
+			rules:  Diversity{MaxPerSource: 2, MinCategories: 3},
+			want:   []string{"0", "1", "2", "3"},
+		},
+		{
+			name:   "fewer articles than n",
+			ranked: synthetic("A/ai", "A/ai"),
+			n:      5,
+			rules:  Diversity{MaxPerSource: 1},
+			want:   []string{"0", "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectedIDs(Select(tt.ranked, tt.n, tt.rules))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateDiversity(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	var articles []cache.Article
+	for i := 0; i < 6; i++ {
+		articles = append(articles, cache.Article{
+			ID:        fmt.Sprintf("openai-%d", i),
+			Source:    "OpenAI",
+			Title:     fmt.Sprintf("Announcement number %d", i),
+			Link:      fmt.Sprintf("https://openai.com/%d", i),
+			Published: now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	for i, source := range []string{"Stripe", "Netflix"} {
+		articles = append(articles, cache.Article{
+			ID:        source,
+			Source:    source,
+			Title:     source + " engineering update",
+			Link:      "https://example.com/" + source,
+			Published: now.Add(-time.Duration(10+i) * time.Hour),
+		})
+	}
+	if err := db.UpsertArticles(articles); err != nil {
+		t.Fatalf("UpsertArticles: %v", err)
+	}
+
+	b, err := Generate(GenerateOpts{
+		DB:        db,
+		Since:     now.Add(-24 * time.Hour),
+		BriefSize: 4,
+		Diversity: Diversity{MaxPerSource: 2},
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	perSource := map[string]int{}
+	for _, c := range b.Cards {
+		perSource[c.Article.Source]++
+	}
+	if len(b.Cards) != 4 || perSource["OpenAI"] != 2 || perSource["Stripe"] != 1 || perSource["Netflix"] != 1 {
+		t.Errorf("expected 2 OpenAI, 1 Stripe, 1 Netflix card; got %v", perSource)
+	}
+}
+
+func TestGenerateFocus(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	articles := []cache.Article{
+		{ID: "llm", Source: "OpenAI", Title: "Training a larger LLM model", Link: "https://example.com/llm", Published: now.Add(-time.Hour)},
+		{ID: "k8s", Source: "Netflix", Title: "Upgrading our Kubernetes clusters", Link: "https://example.com/k8s", Published: now.Add(-2 * time.Hour)},
+		{ID: "pg", Source: "Stripe", Title: "Postgres query planner tuning", Link: "https://example.com/pg", Published: now.Add(-20 * time.Hour)},
+	}
+	if err := db.UpsertArticles(articles); err != nil {
+		t.Fatalf("UpsertArticles: %v", err)
+	}
+
+	b, err := Generate(GenerateOpts{
+		DB:        db,
+		Since:     now.Add(-24 * time.Hour),
+		BriefSize: 5,
+		Focus:     map[string]float64{"Databases": 2, "Security": 1},
+		Exclude:   []string{"AI/ML"},
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	var ids []string
+	for _, c := range b.Cards {
+		ids = append(ids, c.Article.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"pg", "k8s"}) {
+		t.Errorf("expected the focused db post first, the infra post kept and the ai post excluded; got %v", ids)
+	}
+	if b.Focus != "Databases:2, Security, !AI/ML" {
+		t.Errorf("Focus = %q", b.Focus)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 
 	_ "modernc.org/sqlite"
 )
@@ -14,6 +15,7 @@ import (
 type Cache struct {
 	readDB  *sql.DB
 	writeDB *sql.DB
+	path    string
 }
 
 func Open(dbPath string) (*Cache, error) {
@@ -33,7 +35,7 @@ func Open(dbPath string) (*Cache, error) {
 		return nil, fmt.Errorf("opening read db: %w", err)
 	}
 
-	c := &Cache{readDB: readDB, writeDB: writeDB}
+	c := &Cache{readDB: readDB, writeDB: writeDB, path: dbPath}
 	if err := c.init(); err != nil {
 		c.Close()
 		return nil, err
@@ -42,45 +44,7 @@ func Open(dbPath string) (*Cache, error) {
 }
 
 func (c *Cache) init() error {
-	_, err := c.writeDB.Exec(`
-		CREATE TABLE IF NOT EXISTS articles (
-			id          TEXT PRIMARY KEY,
-			source      TEXT NOT NULL,
-			title       TEXT NOT NULL,
-			link        TEXT NOT NULL,
-			description TEXT NOT NULL DEFAULT '',
-			published   DATETIME NOT NULL,
-			fetched_at  DATETIME NOT NULL,
-			summary     TEXT NOT NULL DEFAULT '',
-			tags        TEXT NOT NULL DEFAULT ''
-		);
-		CREATE INDEX IF NOT EXISTS idx_articles_published ON articles(published DESC);
-		CREATE INDEX IF NOT EXISTS idx_articles_source ON articles(source);
-
-		CREATE TABLE IF NOT EXISTS meta (
-			key   TEXT PRIMARY KEY,
-			value TEXT NOT NULL
-		);
-	`)
-	if err != nil {
-		return fmt.Errorf("initializing schema: %w", err)
-	}
-
-	// Migrate: add summary/tags columns if they don't exist
-	c.writeDB.Exec("ALTER TABLE articles ADD COLUMN summary TEXT NOT NULL DEFAULT ''")
-	c.writeDB.Exec("ALTER TABLE articles ADD COLUMN tags TEXT NOT NULL DEFAULT ''")
-
-	// Migrate: add V2 columns
-	c.writeDB.Exec("ALTER TABLE articles ADD COLUMN category TEXT NOT NULL DEFAULT ''")
-	c.writeDB.Exec("ALTER TABLE articles ADD COLUMN why_it_matters TEXT NOT NULL DEFAULT ''")
-
-	// Migrate: add full_summary column for AI article summaries
-	c.writeDB.Exec("ALTER TABLE articles ADD COLUMN full_summary TEXT NOT NULL DEFAULT ''")
-
-	// Migrate: add read column for read tracking
-	c.writeDB.Exec("ALTER TABLE articles ADD COLUMN read INTEGER NOT NULL DEFAULT 0")
-
-	return nil
+	return c.migrate(migrations)
 }
 
 func (c *Cache) Close() error {
@@ -129,6 +93,89 @@ func (c *Cache) UpsertArticles(articles []Article) error {
 	return tx.Commit()
 }
 
+// articleColumns is the column list read by every article query. Columns are
+// qualified so the list can be used when joining against articles_fts.
+const articleColumns = "articles.id, articles.source, articles.title, articles.link, articles.description, articles.published, articles.fetched_at, articles.summary, articles.tags, articles.category, articles.why_it_matters, articles.full_summary, articles.read, articles.starred, articles.category_source"
+
+func scanArticles(rows *sql.Rows) ([]Article, error) {
+	var articles []Article
+	for rows.Next() {
+		var a Article
+		if err := rows.Scan(&a.ID, &a.Source, &a.Title, &a.Link, &a.Description, &a.Published, &a.FetchedAt, &a.Summary, &a.Tags, &a.Category, &a.WhyItMatters, &a.FullSummary, &a.Read, &a.Starred, &a.CategorySource); err != nil {
+			return nil, fmt.Errorf("scanning article: %w", err)
+		}
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
+
+// scanLabeledArticles scans articles and loads their category labels.
+func (c *Cache) scanLabeledArticles(rows *sql.Rows) ([]Article, error) {
+	articles, err := scanArticles(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.loadLabels(articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// ftsTokens splits text into the words FTS5 will see. FTS5 syntax characters
+// are dropped so user input can never produce a malformed query.
+func ftsTokens(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ftsPhrase quotes text as a single FTS5 phrase, or returns "" when nothing
+// searchable remains.
+func ftsPhrase(text string) string {
+	tokens := ftsTokens(text)
+	if len(tokens) == 0 {
+		return ""
+	}
+	return `"` + strings.Join(tokens, " ") + `"`
+}
+
+// matchExpression turns the search options into an FTS5 query: every word
+// must match, and each word also matches as a prefix ("kube" finds
+// "kubernetes"); phrases must match exactly and tags are matched against the
+// tags column only. Returns "" when nothing searchable remains.
+func matchExpression(opts QueryOpts) string {
+	var terms []string
+	for _, word := range ftsTokens(opts.Search) {
+		terms = append(terms, `"`+word+`"*`)
+	}
+	for _, p := range opts.Phrases {
+		if phrase := ftsPhrase(p); phrase != "" {
+			terms = append(terms, phrase)
+		}
+	}
+	for _, tag := range opts.Tags {
+		if phrase := ftsPhrase(tag); phrase != "" {
+			terms = append(terms, "tags : "+phrase)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// excludeExpression builds an FTS5 query matching any of the excluded terms.
+// Excluded words are matched whole, so "-go" does not hide "google".
+func excludeExpression(exclude []string) string {
+	var terms []string
+	for _, e := range exclude {
+		if phrase := ftsPhrase(e); phrase != "" {
+			terms = append(terms, phrase)
+		}
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// likeEscaper escapes LIKE wildcards so source filters match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func (c *Cache) GetArticles(opts QueryOpts) ([]Article, error) {
 	var (
 		where []string
@@ -149,23 +196,64 @@ func (c *Cache) GetArticles(opts QueryOpts) ([]Article, error) {
 		where = append(where, "source IN ("+strings.Join(placeholders, ",")+")") //nolint:gosec
 	}
 
-	if opts.Search != "" {
-		where = append(where, "(title LIKE ? OR description LIKE ?)")
-		term := "%" + opts.Search + "%"
-		args = append(args, term, term)
+	if !opts.Until.IsZero() {
+		where = append(where, "published < ?")
+		args = append(args, opts.Until)
+	}
+
+	if len(opts.SourceNames) > 0 {
+		likes := make([]string, len(opts.SourceNames))
+		for i, name := range opts.SourceNames {
+			likes[i] = `source LIKE ? ESCAPE '\'`
+			args = append(args, "%"+likeEscaper.Replace(name)+"%")
+		}
+		where = append(where, "("+strings.Join(likes, " OR ")+")")
+	}
+
+	if opts.Unread {
+		where = append(where, "read = 0")
+	}
+
+	if opts.Read {
+		where = append(where, "read = 1")
+	}
+
+	if opts.Starred {
+		where = append(where, "starred = 1")
+	}
+
+	if opts.Unclassified {
+		where = append(where, "articles.category = ''")
+	}
+
+	from := "articles"
+	order := "articles.published DESC"
+	if expr := matchExpression(opts); expr != "" {
+		from += " JOIN articles_fts ON articles_fts.rowid = articles.rowid"
+		where = append(where, "articles_fts MATCH ?")
+		args = append(args, expr)
+		if !opts.OrderByDate {
+			// Column weights: id, title, description, summary, tags, why_it_matters, full_summary
+			order = "bm25(articles_fts, 0, 10, 4, 3, 3, 2, 1), articles.published DESC"
+		}
+	}
+
+	if expr := excludeExpression(opts.Exclude); expr != "" {
+		where = append(where, "articles.rowid NOT IN (SELECT rowid FROM articles_fts WHERE articles_fts MATCH ?)")
+		args = append(args, expr)
 	}
 
 	if opts.Category != "" {
-		where = append(where, "category = ?")
-		args = append(args, opts.Category)
+		where = append(where, "(articles.category = ? OR articles.id IN (SELECT article_id FROM article_categories WHERE category = ?))")
+		args = append(args, opts.Category, opts.Category)
 	}
 
-	query := "SELECT id, source, title, link, description, published, fetched_at, summary, tags, category, why_it_matters, full_summary, read FROM articles"
+	query := "SELECT " + articleColumns + " FROM " + from
 	if len(where) > 0 {
 		query += " WHERE " + strings.Join(where, " AND ")
 	}
 
-	query += " ORDER BY published DESC"
+	query += " ORDER BY " + order
 
 	limit := opts.Limit
 	if limit <= 0 {
@@ -179,15 +267,7 @@ func (c *Cache) GetArticles(opts QueryOpts) ([]Article, error) {
 	}
 	defer rows.Close()
 
-	var articles []Article
-	for rows.Next() {
-		var a Article
-		if err := rows.Scan(&a.ID, &a.Source, &a.Title, &a.Link, &a.Description, &a.Published, &a.FetchedAt, &a.Summary, &a.Tags, &a.Category, &a.WhyItMatters, &a.FullSummary, &a.Read); err != nil {
-			return nil, fmt.Errorf("scanning article: %w", err)
-		}
-		articles = append(articles, a)
-	}
-	return articles, rows.Err()
+	return c.scanLabeledArticles(rows)
 }
 
 func (c *Cache) NeedsRefresh(interval time.Duration) bool {
@@ -203,11 +283,16 @@ func (c *Cache) NeedsRefresh(interval time.Duration) bool {
 	return time.Since(t) > interval
 }
 
-// Prune deletes articles older than the given retention duration and runs VACUUM.
-// Returns the number of deleted rows.
+// Prune deletes articles older than the given retention duration and runs
+// VACUUM, then rebuilds the search index in case VACUUM renumbered rowids.
+// Starred articles and unread queue items are kept regardless of age. Returns
+// the number of deleted rows.
 func (c *Cache) Prune(retention time.Duration) (int64, error) {
 	cutoff := time.Now().Add(-retention)
-	result, err := c.writeDB.Exec("DELETE FROM articles WHERE published < ?", cutoff)
+	result, err := c.writeDB.Exec(`
+		DELETE FROM articles WHERE published < ? AND starred = 0
+		AND id NOT IN (SELECT article_id FROM reading_queue WHERE finished_at IS NULL)
+	`, cutoff)
 	if err != nil {
 		return 0, fmt.Errorf("pruning articles: %w", err)
 	}
@@ -217,6 +302,9 @@ func (c *Cache) Prune(retention time.Duration) (int64, error) {
 		if _, err := c.writeDB.Exec("VACUUM"); err != nil {
 			return deleted, fmt.Errorf("vacuum after prune: %w", err)
 		}
+		if _, err := c.writeDB.Exec("INSERT INTO articles_fts (articles_fts) VALUES ('rebuild')"); err != nil {
+			return deleted, fmt.Errorf("rebuilding search index: %w", err)
+		}
 	}
 	return deleted, nil
 }
@@ -313,7 +401,7 @@ func (c *Cache) SetLastOpened() error {
 // GetArticlesSince returns articles published after the given time.
 func (c *Cache) GetArticlesSince(since time.Time) ([]Article, error) {
 	rows, err := c.readDB.Query(
-		"SELECT id, source, title, link, description, published, fetched_at, summary, tags, category, why_it_matters, full_summary, read FROM articles WHERE published >= ? ORDER BY published DESC",
+		"SELECT "+articleColumns+" FROM articles WHERE published >= ? ORDER BY published DESC",
 		since,
 	)
 	if err != nil {
@@ -321,21 +409,7 @@ func (c *Cache) GetArticlesSince(since time.Time) ([]Article, error) {
 	}
 	defer rows.Close()
 
-	var articles []Article
-	for rows.Next() {
-		var a Article
-		if err := rows.Scan(&a.ID, &a.Source, &a.Title, &a.Link, &a.Description, &a.Published, &a.FetchedAt, &a.Summary, &a.Tags, &a.Category, &a.WhyItMatters, &a.FullSummary, &a.Read); err != nil {
-			return nil, err
-		}
-		articles = append(articles, a)
-	}
-	return articles, rows.Err()
-}
-
-// UpdateArticleCategory saves the category for an article.
-func (c *Cache) UpdateArticleCategory(id, category string) error {
-	_, err := c.writeDB.Exec("UPDATE articles SET category = ? WHERE id = ?", category, id)
-	return err
+	return c.scanLabeledArticles(rows)
 }
 
 // UpdateArticleWhyItMatters saves the "why it matters" text for an article.
@@ -356,6 +430,148 @@ func (c *Cache) MarkArticleRead(id string) error {
 	return err
 }
 
+// SetStarred stars or unstars an article.
+func (c *Cache) SetStarred(id string, starred bool) error {
+	_, err := c.writeDB.Exec("UPDATE articles SET starred = ? WHERE id = ?", starred, id)
+	return err
+}
+
+// FeedValidators returns the stored ETag and Last-Modified values for a source.
+// A source that has never been fetched returns zero validators and no error.
+func (c *Cache) FeedValidators(source string) (FeedValidators, error) {
+	var v FeedValidators
+	var backfillSeconds int64
+	err := c.readDB.QueryRow("SELECT etag, last_modified, backfill_seconds FROM feed_validators WHERE source = ?", source).Scan(&v.ETag, &v.LastModified, &backfillSeconds)
+	if err == sql.ErrNoRows {
+		return FeedValidators{}, nil
+	}
+	v.Backfill = time.Duration(backfillSeconds) * time.Second
+	return v, err
+}
+
+// SetFeedValidators stores the ETag and Last-Modified values for a source,
+// with the backfill window they were fetched with.
+func (c *Cache) SetFeedValidators(source string, v FeedValidators) error {
+	_, err := c.writeDB.Exec(`
+		INSERT INTO feed_validators (source, etag, last_modified, backfill_seconds) VALUES (?, ?, ?, ?)
+		ON CONFLICT(source) DO UPDATE SET etag = excluded.etag, last_modified = excluded.last_modified,
+			backfill_seconds = excluded.backfill_seconds
+	`, source, v.ETag, v.LastModified, int64(v.Backfill/time.Second))
+	return err
+}
+
+// RecordFetch updates the health record for a source after a fetch attempt.
+// A not-modified response counts as a success but keeps the previous item count.
+func (c *Cache) RecordFetch(a FetchAttempt) error {
+	now := time.Now()
+	latency := a.Latency.Milliseconds()
+
+	if a.Err != nil {
+		_, err := c.writeDB.Exec(`
+			INSERT INTO source_health (source, last_error, last_error_at, consecutive_failures, latency_ms, last_checked)
+			VALUES (?, ?, ?, 1, ?, ?)
+			ON CONFLICT(source) DO UPDATE SET
+				last_error = excluded.last_error,
+				last_error_at = excluded.last_error_at,
+				consecutive_failures = consecutive_failures + 1,
+				latency_ms = excluded.latency_ms,
+				last_checked = excluded.last_checked
+		`, a.Source, a.Err.Error(), now, latency, now)
+		return err
+	}
+
+	if a.NotModified {
+		_, err := c.writeDB.Exec(`
+			INSERT INTO source_health (source, last_success, consecutive_failures, latency_ms, last_checked)
+			VALUES (?, ?, 0, ?, ?)
+			ON CONFLICT(source) DO UPDATE SET
+				last_success = excluded.last_success,
+				consecutive_failures = 0,
+				latency_ms = excluded.latency_ms,
+				last_checked = excluded.last_checked
+		`, a.Source, now, latency, now)
+		return err
+	}
+
+	_, err := c.writeDB.Exec(`
+		INSERT INTO source_health (source, last_success, consecutive_failures, item_count, latency_ms, last_checked)
+		VALUES (?, ?, 0, ?, ?, ?)
+		ON CONFLICT(source) DO UPDATE SET
+			last_success = excluded.last_success,
+			consecutive_failures = 0,
+			item_count = excluded.item_count,
+			latency_ms = excluded.latency_ms,
+			last_checked = excluded.last_checked
+	`, a.Source, now, a.Items, latency, now)
+	return err
+}
+
+// SourceHealth returns the health records of every source that has been fetched,
+// ordered by source name.
+func (c *Cache) SourceHealth() ([]SourceHealth, error) {
+	rows, err := c.readDB.Query(`
+		SELECT source, last_success, last_error, last_error_at, consecutive_failures, item_count, latency_ms, last_checked
+		FROM source_health ORDER BY source
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying source health: %w", err)
+	}
+	defer rows.Close()
+
+	var out []SourceHealth
+	for rows.Next() {
+		var (
+			h                      SourceHealth
+			lastSuccess, lastError sql.NullTime
+			latencyMS              int64
+		)
+		if err := rows.Scan(&h.Source, &lastSuccess, &h.LastError, &lastError, &h.ConsecutiveFailures, &h.ItemCount, &latencyMS, &h.LastChecked); err != nil {
+			return nil, fmt.Errorf("scanning source health: %w", err)
+		}
+		h.LastSuccess = lastSuccess.Time
+		h.LastErrorAt = lastError.Time
+		h.Latency = time.Duration(latencyMS) * time.Millisecond
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
+
+// EditSource updates what the cache keeps per source after a source is
+// renamed from oldName to newName or its URL changes. A new URL drops the
+// stored validators and health, which describe the old feed; a new name
+// moves them and the source's articles to it. It runs in one transaction.
+func (c *Cache) EditSource(oldName, newName string, urlChanged bool) error {
+	tx, err := c.writeDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var stmts []string
+	if urlChanged {
+		stmts = append(stmts,
+			"DELETE FROM feed_validators WHERE source = ?1",
+			"DELETE FROM source_health WHERE source = ?1",
+		)
+	}
+	if newName != oldName {
+		stmts = append(stmts,
+			// Rows left behind by an earlier source of the new name.
+			"DELETE FROM feed_validators WHERE source = ?2",
+			"DELETE FROM source_health WHERE source = ?2",
+			"UPDATE feed_validators SET source = ?2 WHERE source = ?1",
+			"UPDATE source_health SET source = ?2 WHERE source = ?1",
+			"UPDATE articles SET source = ?2 WHERE source = ?1",
+		)
+	}
+	for _, q := range stmts {
+		if _, err := tx.Exec(q, oldName, newName); err != nil {
+			return fmt.Errorf("updating source %s: %w", oldName, err)
+		}
+	}
+	return tx.Commit()
+}
+
 // ShouldCheckUpdate returns true if the last update check was more than 24 hours ago or never happened.
 func (c *Cache) ShouldCheckUpdate() bool {
 	value, err := c.getMeta("last_update_check")
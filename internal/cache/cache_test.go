@@ -1,8 +1,10 @@
 package cache
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -127,6 +129,159 @@ func TestQuerySearch(t *testing.T) {
 	}
 }
 
+func TestSearchMultiWordAndPrefix(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	got, err := db.GetArticles(QueryOpts{Search: "desc sear"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "ccc" {
+		t.Errorf("expected only ccc for prefix multi-word search, got %v", articleIDs(got))
+	}
+
+	// Every word must match
+	got, _ = db.GetArticles(QueryOpts{Search: "desc kubernetes"})
+	if len(got) != 0 {
+		t.Errorf("expected no results when one word is missing, got %v", articleIDs(got))
+	}
+
+	// Input made only of query syntax is ignored rather than rejected
+	got, err = db.GetArticles(QueryOpts{Search: `"*()`})
+	if err != nil {
+		t.Fatalf("get with syntax-only search: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("expected all 3 articles, got %d", len(got))
+	}
+}
+
+func TestSearchAIFieldsStayInSync(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	db.UpdateArticleSummary("aaa", "Rewriting the DNS proxy", "rust, dns")
+	db.UpdateArticleWhyItMatters("bbb", "Postgres replication lag matters")
+	db.UpdateArticleFullSummary("ccc", "A deep dive into observability pipelines")
+
+	for search, want := range map[string]string{"rust": "aaa", "replication": "bbb", "observability": "ccc"} {
+		got, err := db.GetArticles(QueryOpts{Search: search})
+		if err != nil {
+			t.Fatalf("search %q: %v", search, err)
+		}
+		if len(got) != 1 || got[0].ID != want {
+			t.Errorf("search %q: expected %s, got %v", search, want, articleIDs(got))
+		}
+	}
+
+	// Replaced text is no longer searchable
+	db.UpdateArticleSummary("aaa", "Something else", "")
+	got, _ := db.GetArticles(QueryOpts{Search: "rust"})
+	if len(got) != 0 {
+		t.Errorf("expected stale summary to be removed from the index, got %v", articleIDs(got))
+	}
+}
+
+func TestSearchRanking(t *testing.T) {
+	db := testDB(t)
+	now := time.Now()
+	db.UpsertArticles([]Article{
+		{ID: "new", Source: "A", Title: "Weekly roundup", Link: "https://a.com/1", Description: "Mentions kafka once", Published: now, FetchedAt: now},
+		{ID: "old", Source: "B", Title: "Kafka at scale: kafka consumers", Link: "https://b.com/2", Description: "All about kafka", Published: now.Add(-48 * time.Hour), FetchedAt: now},
+	})
+
+	got, err := db.GetArticles(QueryOpts{Search: "kafka"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "old" {
+		t.Errorf("expected title match ranked first, got %v", articleIDs(got))
+	}
+
+	got, _ = db.GetArticles(QueryOpts{Search: "kafka", OrderByDate: true})
+	if len(got) != 2 || got[0].ID != "new" {
+		t.Errorf("expected newest first with OrderByDate, got %v", articleIDs(got))
+	}
+}
+
+func TestSearchAfterPrune(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if _, err := db.Prune(24 * time.Hour); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+
+	got, err := db.GetArticles(QueryOpts{Search: "search"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected pruned article gone from search, got %v", articleIDs(got))
+	}
+}
+
+func TestStructuredFilters(t *testing.T) {
+	db := testDB(t)
+	now := time.Now()
+	db.UpsertArticles([]Article{
+		{ID: "k8s", Source: "Pragmatic Engineer", Title: "Zero downtime deploys on Kubernetes", Link: "https://p.com/1", Description: "Rolling updates", Published: now.Add(-1 * time.Hour), FetchedAt: now},
+		{ID: "vm", Source: "Stripe", Title: "Zero downtime deploys on VMs", Link: "https://s.com/2", Description: "Blue green 100%_done", Published: now.Add(-2 * time.Hour), FetchedAt: now},
+		{ID: "old", Source: "Stripe", Title: "Downtime postmortem", Link: "https://s.com/3", Description: "An outage and zero deploys", Published: now.Add(-72 * time.Hour), FetchedAt: now},
+	})
+	db.UpdateArticleSummary("vm", "", "rust, deploys")
+	db.MarkArticleRead("k8s")
+
+	tests := []struct {
+		name string
+		opts QueryOpts
+		want []string
+	}{
+		{"phrase", QueryOpts{Phrases: []string{"zero downtime"}}, []string{"k8s", "vm"}},
+		{"exclude word", QueryOpts{Search: "deploys", Exclude: []string{"kubernetes"}}, []string{"vm", "old"}},
+		{"exclude only", QueryOpts{Exclude: []string{"zero downtime"}}, []string{"old"}},
+		{"exclude is whole word", QueryOpts{Exclude: []string{"kube"}}, []string{"k8s", "vm", "old"}},
+		{"tag", QueryOpts{Tags: []string{"rust"}}, []string{"vm"}},
+		{"source name", QueryOpts{SourceNames: []string{"pragmatic"}}, []string{"k8s"}},
+		{"source names any", QueryOpts{SourceNames: []string{"STRIPE", "pragmatic"}}, []string{"k8s", "vm", "old"}},
+		{"source wildcard literal", QueryOpts{SourceNames: []string{"%"}}, nil},
+		{"unread", QueryOpts{Unread: true}, []string{"vm", "old"}},
+		{"until", QueryOpts{Until: now.Add(-24 * time.Hour)}, []string{"old"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.OrderByDate = true
+			got, err := db.GetArticles(tt.opts)
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			ids := articleIDs(got)
+			if len(ids) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("expected %v, got %v", tt.want, ids)
+				}
+			}
+		})
+	}
+}
+
+func articleIDs(articles []Article) []string {
+	ids := make([]string, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
 func TestQueryCombinedFilters(t *testing.T) {
 	db := testDB(t)
 	if err := db.UpsertArticles(sampleArticles()); err != nil {
@@ -221,6 +376,32 @@ func TestPruneDeletesOldArticles(t *testing.T) {
 	}
 }
 
+func TestSearchIndexFollowsPruneAndUpdates(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if _, err := db.Prune(24 * time.Hour); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if err := db.UpdateArticleSummary("aaa", "Kernel scheduler notes", "linux"); err != nil {
+		t.Fatalf("update summary: %v", err)
+	}
+
+	if got, _ := db.GetArticles(QueryOpts{Search: "search"}); len(got) != 0 {
+		t.Errorf("expected the pruned article gone from search, got %v", articleIDs(got))
+	}
+	if got, _ := db.GetArticles(QueryOpts{Search: "scheduler"}); len(got) != 1 || got[0].ID != "aaa" {
+		t.Errorf("expected the updated summary searchable, got %v", articleIDs(got))
+	}
+	if got, _ := db.GetArticles(QueryOpts{Exclude: []string{"scheduler"}}); len(got) != 1 || got[0].ID != "bbb" {
+		t.Errorf("expected the excluded article filtered out, got %v", articleIDs(got))
+	}
+	if _, err := db.writeDB.Exec("INSERT INTO articles_fts (articles_fts) VALUES ('integrity-check')"); err != nil {
+		t.Errorf("search index out of sync with articles: %v", err)
+	}
+}
+
 func TestPruneNothingToDelete(t *testing.T) {
 	db := testDB(t)
 	if err := db.UpsertArticles(sampleArticles()); err != nil {
@@ -367,28 +548,6 @@ func TestGetArticlesSince(t *testing.T) {
 	}
 }
 
-func TestUpdateArticleCategory(t *testing.T) {
-	db := testDB(t)
-	if err := db.UpsertArticles(sampleArticles()); err != nil {
-		t.Fatalf("upsert: %v", err)
-	}
-
-	if err := db.UpdateArticleCategory("aaa", "Infrastructure"); err != nil {
-		t.Fatalf("UpdateArticleCategory: %v", err)
-	}
-
-	articles, _ := db.GetArticles(QueryOpts{})
-	for _, a := range articles {
-		if a.ID == "aaa" {
-			if a.Category != "Infrastructure" {
-				t.Errorf("expected category Infrastructure, got %q", a.Category)
-			}
-			return
-		}
-	}
-	t.Error("article aaa not found")
-}
-
 func TestUpdateArticleWhyItMatters(t *testing.T) {
 	db := testDB(t)
 	if err := db.UpsertArticles(sampleArticles()); err != nil {
@@ -417,9 +576,10 @@ func TestCategoryFilter(t *testing.T) {
 		t.Fatalf("upsert: %v", err)
 	}
 
-	db.UpdateArticleCategory("aaa", "Infrastructure")
-	db.UpdateArticleCategory("bbb", "AI/ML")
-	db.UpdateArticleCategory("ccc", "Infrastructure")
+	infra := []Label{{Category: "Infrastructure", Score: 1}}
+	db.SetArticleLabels("aaa", infra, CategorySourceKeyword)
+	db.SetArticleLabels("bbb", []Label{{Category: "AI/ML", Score: 1}}, CategorySourceKeyword)
+	db.SetArticleLabels("ccc", infra, CategorySourceKeyword)
 
 	got, err := db.GetArticles(QueryOpts{Category: "Infrastructure"})
 	if err != nil {
@@ -430,6 +590,53 @@ func TestCategoryFilter(t *testing.T) {
 	}
 }
 
+func TestArticleLabels(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	labels := []Label{{Category: "Databases", Score: 0.6}, {Category: "Infrastructure", Score: 0.4}}
+	if err := db.SetArticleLabels("aaa", labels, CategorySourceAI); err != nil {
+		t.Fatalf("SetArticleLabels: %v", err)
+	}
+	db.SetArticleLabels("bbb", []Label{{Category: "Infrastructure", Score: 1}}, CategorySourceKeyword)
+
+	got, err := db.GetArticles(QueryOpts{Category: "Infrastructure"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if ids := articleIDs(got); len(ids) != 2 || ids[0] != "aaa" || ids[1] != "bbb" {
+		t.Errorf("expected primary and secondary Infrastructure matches, got %v", ids)
+	}
+	if got[0].Category != "Databases" || !reflect.DeepEqual(got[0].Labels, labels) {
+		t.Errorf("expected ranked labels with Databases as primary, got %q %+v", got[0].Category, got[0].Labels)
+	}
+	if got[0].CategorySource != CategorySourceAI || got[1].CategorySource != CategorySourceKeyword {
+		t.Errorf("expected category sources to be stored, got %q and %q", got[0].CategorySource, got[1].CategorySource)
+	}
+	if !got[0].InCategory("Infrastructure") || got[0].InCategory("AI/ML") {
+		t.Error("InCategory should match primary and secondary labels only")
+	}
+
+	// Relabeling replaces the old labels.
+	db.SetArticleLabels("aaa", []Label{{Category: "Security", Score: 1}}, CategorySourceKeyword)
+	got, _ = db.GetArticlesSince(time.Now().Add(-3 * time.Hour))
+	if len(got) != 2 || len(got[0].Labels) != 1 || got[0].Category != "Security" || got[0].CategorySource != CategorySourceKeyword {
+		t.Errorf("expected aaa relabeled as Security, got %+v", got)
+	}
+
+	// Labels go with their article.
+	if _, err := db.Prune(time.Hour / 2); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	var n int
+	db.readDB.QueryRow("SELECT COUNT(*) FROM article_categories").Scan(&n)
+	if n != 0 {
+		t.Errorf("expected labels pruned with their articles, %d left", n)
+	}
+}
+
 func TestUpdateArticleFullSummary(t *testing.T) {
 	db := testDB(t)
 	if err := db.UpsertArticles(sampleArticles()); err != nil {
@@ -480,6 +687,175 @@ func TestMarkArticleRead(t *testing.T) {
 			t.Errorf("article %s should still be unread", a.ID)
 		}
 	}
+
+	read, _ := db.GetArticles(QueryOpts{Read: true})
+	if len(read) != 1 || read[0].ID != "aaa" {
+		t.Errorf("Read filter returned %d articles, want only aaa", len(read))
+	}
+}
+
+func TestSetStarred(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	if err := db.SetStarred("bbb", true); err != nil {
+		t.Fatalf("SetStarred: %v", err)
+	}
+	got, err := db.GetArticles(QueryOpts{Starred: true})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "bbb" || !got[0].Starred {
+		t.Fatalf("expected only bbb starred, got %v", articleIDs(got))
+	}
+
+	if err := db.SetStarred("bbb", false); err != nil {
+		t.Fatalf("SetStarred: %v", err)
+	}
+	got, _ = db.GetArticles(QueryOpts{Starred: true})
+	if len(got) != 0 {
+		t.Errorf("expected no starred articles, got %v", articleIDs(got))
+	}
+}
+
+func TestPruneKeepsStarred(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	db.SetStarred("ccc", true)
+
+	deleted, err := db.Prune(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("expected starred article to survive prune, %d deleted", deleted)
+	}
+
+	// Upserting again from the feed must not clear the star
+	db.UpsertArticles(sampleArticles())
+	got, _ := db.GetArticles(QueryOpts{Starred: true})
+	if len(got) != 1 || got[0].ID != "ccc" {
+		t.Errorf("expected ccc still starred, got %v", articleIDs(got))
+	}
+}
+
+func TestFeedValidators(t *testing.T) {
+	db := testDB(t)
+
+	v, err := db.FeedValidators("Cloudflare")
+	if err != nil {
+		t.Fatalf("FeedValidators on unknown source: %v", err)
+	}
+	if v != (FeedValidators{}) {
+		t.Errorf("expected zero validators, got %+v", v)
+	}
+
+	want := FeedValidators{ETag: `"abc"`, LastModified: "Mon, 02 Jan 2006 15:04:05 GMT"}
+	if err := db.SetFeedValidators("Cloudflare", want); err != nil {
+		t.Fatalf("SetFeedValidators: %v", err)
+	}
+	got, err := db.FeedValidators("Cloudflare")
+	if err != nil {
+		t.Fatalf("FeedValidators: %v", err)
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestEditSource(t *testing.T) {
+	db := testDB(t)
+	db.UpsertArticles(sampleArticles())
+	v := FeedValidators{ETag: `"abc"`}
+	db.SetFeedValidators("Cloudflare", v)
+	db.RecordFetch(FetchAttempt{Source: "Cloudflare", Items: 2})
+	db.SetFeedValidators("CF", FeedValidators{ETag: `"stale"`}) // from an old source of the new name
+
+	// A rename moves validators, health and articles.
+	if err := db.EditSource("Cloudflare", "CF", false); err != nil {
+		t.Fatalf("EditSource: %v", err)
+	}
+	if got, _ := db.FeedValidators("CF"); got != v {
+		t.Errorf("expected validators moved to the new name, got %+v", got)
+	}
+	if got, _ := db.FeedValidators("Cloudflare"); got != (FeedValidators{}) {
+		t.Errorf("expected no validators left under the old name, got %+v", got)
+	}
+	if records, _ := db.SourceHealth(); len(records) != 1 || records[0].Source != "CF" || records[0].ItemCount != 2 {
+		t.Errorf("expected health moved to the new name, got %+v", records)
+	}
+	if articles, _ := db.GetArticles(QueryOpts{Sources: []string{"CF"}}); len(articles) != 2 {
+		t.Errorf("expected both articles moved to the new name, got %d", len(articles))
+	}
+
+	// A new URL drops validators and health, so the new feed is fetched in
+	// full.
+	if err := db.EditSource("CF", "CF", true); err != nil {
+		t.Fatalf("EditSource: %v", err)
+	}
+	if got, _ := db.FeedValidators("CF"); got != (FeedValidators{}) {
+		t.Errorf("expected validators dropped, got %+v", got)
+	}
+	if records, _ := db.SourceHealth(); len(records) != 0 {
+		t.Errorf("expected health dropped, got %+v", records)
+	}
+	if articles, _ := db.GetArticles(QueryOpts{Sources: []string{"CF"}}); len(articles) != 2 {
+		t.Errorf("expected articles kept, got %d", len(articles))
+	}
+}
+
+func TestRecordFetch(t *testing.T) {
+	db := testDB(t)
+
+	if err := db.RecordFetch(FetchAttempt{Source: "Stripe", Items: 12, Latency: 150 * time.Millisecond}); err != nil {
+		t.Fatalf("RecordFetch success: %v", err)
+	}
+	for i := 0; i < FailingThreshold; i++ {
+		if err := db.RecordFetch(FetchAttempt{Source: "Stripe", Err: errors.New("404 Not Found")}); err != nil {
+			t.Fatalf("RecordFetch failure: %v", err)
+		}
+	}
+
+	records, err := db.SourceHealth()
+	if err != nil {
+		t.Fatalf("SourceHealth: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	h := records[0]
+	if h.ConsecutiveFailures != FailingThreshold || !h.Failing() {
+		t.Errorf("expected %d consecutive failures and Failing, got %d", FailingThreshold, h.ConsecutiveFailures)
+	}
+	if h.ItemCount != 12 {
+		t.Errorf("expected item count kept at 12, got %d", h.ItemCount)
+	}
+	if h.LastSuccess.IsZero() || h.LastErrorAt.IsZero() {
+		t.Errorf("expected both last success and last error times, got %+v", h)
+	}
+	if h.LastError != "404 Not Found" {
+		t.Errorf("expected last error recorded, got %q", h.LastError)
+	}
+
+	// A not-modified response resets failures without touching the item count
+	if err := db.RecordFetch(FetchAttempt{Source: "Stripe", NotModified: true, Latency: 40 * time.Millisecond}); err != nil {
+		t.Fatalf("RecordFetch not modified: %v", err)
+	}
+	records, _ = db.SourceHealth()
+	h = records[0]
+	if h.ConsecutiveFailures != 0 || h.Failing() {
+		t.Errorf("expected failures reset, got %d", h.ConsecutiveFailures)
+	}
+	if h.ItemCount != 12 {
+		t.Errorf("expected item count 12 after 304, got %d", h.ItemCount)
+	}
+	if h.Latency != 40*time.Millisecond {
+		t.Errorf("expected latency 40ms, got %v", h.Latency)
+	}
 }
 
 func TestOpenCreatesDir(t *testing.T) {
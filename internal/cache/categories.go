@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+)
+
+// labelBatchSize bounds the number of ids per query when loading labels, to
+// stay under SQLite's host parameter limit.
+const labelBatchSize = 500
+
+// SetArticleLabels replaces an article's category labels, ranked in the
+// order given, and stores the first one as its primary category. source is
+// one of the CategorySource constants.
+func (c *Cache) SetArticleLabels(id string, labels []Label, source string) error {
+	tx, err := c.writeDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM article_categories WHERE article_id = ?", id); err != nil {
+		return fmt.Errorf("clearing labels for %s: %w", id, err)
+	}
+	for rank, l := range labels {
+		_, err := tx.Exec(
+			"INSERT INTO article_categories (article_id, category, score, rank) VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING",
+			id, l.Category, l.Score, rank,
+		)
+		if err != nil {
+			return fmt.Errorf("saving labels for %s: %w", id, err)
+		}
+	}
+	primary := ""
+	if len(labels) > 0 {
+		primary = labels[0].Category
+	}
+	if _, err := tx.Exec("UPDATE articles SET category = ?, category_source = ? WHERE id = ?", primary, source, id); err != nil {
+		return fmt.Errorf("saving category for %s: %w", id, err)
+	}
+	return tx.Commit()
+}
+
+// loadLabels fills in the Labels of articles from article_categories.
+func (c *Cache) loadLabels(articles []Article) error {
+	index := make(map[string]int, len(articles))
+	for i, a := range articles {
+		index[a.ID] = i
+	}
+	for start := 0; start < len(articles); start += labelBatchSize {
+		batch := articles[start:min(start+labelBatchSize, len(articles))]
+		args := make([]interface{}, len(batch))
+		for i, a := range batch {
+			args[i] = a.ID
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+		rows, err := c.readDB.Query(
+			"SELECT article_id, category, score FROM article_categories WHERE article_id IN ("+placeholders+") ORDER BY article_id, rank", //nolint:gosec
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("loading labels: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			var l Label
+			if err := rows.Scan(&id, &l.Category, &l.Score); err != nil {
+				rows.Close()
+				return fmt.Errorf("scanning label: %w", err)
+			}
+			if i, ok := index[id]; ok {
+				articles[i].Labels = append(articles[i].Labels, l)
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
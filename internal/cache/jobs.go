@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EnqueueAIJob records that an AI request of kind is wanted for an article.
+// Queuing a job that is already queued is a no-op.
+func (c *Cache) EnqueueAIJob(id, kind string) error {
+	now := time.Now()
+	_, err := c.writeDB.Exec(`
+		INSERT INTO ai_jobs (article_id, kind, created_at, updated_at)
+		SELECT id, ?, ?, ? FROM articles WHERE id = ?
+		ON CONFLICT(article_id, kind) DO NOTHING
+	`, kind, now, now, id)
+	if err != nil {
+		return fmt.Errorf("queuing AI job: %w", err)
+	}
+	return nil
+}
+
+// PendingAIJobs returns up to limit queued jobs that have failed fewer than
+// MaxAIJobAttempts times, oldest first. A limit of zero returns them all.
+func (c *Cache) PendingAIJobs(limit int) ([]AIJob, error) {
+	query := `
+		SELECT ` + articleColumns + `, j.kind, j.attempts, j.last_error, j.created_at
+		FROM ai_jobs j JOIN articles ON articles.id = j.article_id
+		WHERE j.attempts < ?
+		ORDER BY j.created_at ASC, j.article_id ASC`
+	args := []interface{}{MaxAIJobAttempts}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := c.readDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying AI jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []AIJob
+	for rows.Next() {
+		var j AIJob
+		a := &j.Article
+		if err := rows.Scan(&a.ID, &a.Source, &a.Title, &a.Link, &a.Description, &a.Published, &a.FetchedAt, &a.Summary, &a.Tags, &a.Category, &a.WhyItMatters, &a.FullSummary, &a.Read, &a.Starred, &a.CategorySource, &j.Kind, &j.Attempts, &j.LastError, &j.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning AI job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+// AIJobGivenUp reports whether a job has failed MaxAIJobAttempts times, so
+// it should not be queued or run again.
+func (c *Cache) AIJobGivenUp(id, kind string) (bool, error) {
+	var attempts int
+	err := c.readDB.QueryRow("SELECT attempts FROM ai_jobs WHERE article_id = ? AND kind = ?", id, kind).Scan(&attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading AI job: %w", err)
+	}
+	return attempts >= MaxAIJobAttempts, nil
+}
+
+// FinishAIJob removes a job that succeeded from the queue.
+func (c *Cache) FinishAIJob(id, kind string) error {
+	_, err := c.writeDB.Exec("DELETE FROM ai_jobs WHERE article_id = ? AND kind = ?", id, kind)
+	return err
+}
+
+// FailAIJob records a failed attempt at a job. Jobs are no longer returned
+// by PendingAIJobs once they have failed MaxAIJobAttempts times.
+func (c *Cache) FailAIJob(id, kind string, jobErr error) error {
+	_, err := c.writeDB.Exec(`
+		UPDATE ai_jobs SET attempts = attempts + 1, last_error = ?, updated_at = ?
+		WHERE article_id = ? AND kind = ?
+	`, jobErr.Error(), time.Now(), id, kind)
+	return err
+}
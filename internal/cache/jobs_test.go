@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAIJobs(t *testing.T) {
+	db := testDB(t)
+	db.UpsertArticles(sampleArticles())
+
+	db.EnqueueAIJob("aaa", AIJobSummarize)
+	db.EnqueueAIJob("bbb", AIJobWhyItMatters)
+	db.EnqueueAIJob("aaa", AIJobSummarize) // already queued
+	if err := db.EnqueueAIJob("missing", AIJobSummarize); err != nil {
+		t.Fatalf("queuing a job for a missing article: %v", err)
+	}
+
+	jobs, err := db.PendingAIJobs(0)
+	if err != nil {
+		t.Fatalf("PendingAIJobs: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Article.ID != "aaa" || jobs[0].Kind != AIJobSummarize || jobs[0].Article.Title != "Post A" {
+		t.Fatalf("expected the two queued jobs with their articles, got %+v", jobs)
+	}
+	if jobs, _ := db.PendingAIJobs(1); len(jobs) != 1 {
+		t.Errorf("expected limit to apply, got %d jobs", len(jobs))
+	}
+
+	db.FinishAIJob("aaa", AIJobSummarize)
+	for i := 0; i < MaxAIJobAttempts; i++ {
+		if err := db.FailAIJob("bbb", AIJobWhyItMatters, errors.New("429")); err != nil {
+			t.Fatalf("FailAIJob: %v", err)
+		}
+		jobs, _ = db.PendingAIJobs(0)
+		if i < MaxAIJobAttempts-1 && (len(jobs) != 1 || jobs[0].Attempts != i+1 || jobs[0].LastError != "429") {
+			t.Fatalf("after %d failures: %+v", i+1, jobs)
+		}
+	}
+	if len(jobs) != 0 {
+		t.Errorf("expected the job given up on after %d failures, got %+v", MaxAIJobAttempts, jobs)
+	}
+}
+
+func TestAIJobsPrunedWithArticle(t *testing.T) {
+	db := testDB(t)
+	db.UpsertArticles(sampleArticles())
+	db.EnqueueAIJob("ccc", AIJobSummarize)
+
+	if _, err := db.Prune(24 * time.Hour); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	var n int
+	db.readDB.QueryRow("SELECT COUNT(*) FROM ai_jobs").Scan(&n)
+	if n != 0 {
+		t.Errorf("expected the job removed with its article, %d left", n)
+	}
+}
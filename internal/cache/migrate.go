@@ -0,0 +1,438 @@
+package cache
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ErrSchemaTooNew is returned by Open when the database was written by a newer
+// version of devnews than the running binary.
+var ErrSchemaTooNew = errors.New("cache schema is newer than this version of devnews")
+
+// migration is one numbered schema change. Migrations run in order inside a
+// transaction, and the schema version stored in meta is advanced in the same
+// transaction, so a failed migration leaves the database untouched.
+//
+// Databases created before versioning existed report version 0 but may
+// already contain any of the changes in migrations 1-8, so those must be
+// idempotent. Later migrations only ever run once.
+type migration struct {
+	version     int
+	description string
+	// destructive migrations drop or rewrite data; the database file is
+	// backed up before they run.
+	destructive bool
+	up          func(tx *sql.Tx) error
+}
+
+var migrations = []migration{
+	{version: 1, description: "create articles and meta tables", up: createBaseTables},
+	{version: 2, description: "add summary and tags columns", up: func(tx *sql.Tx) error {
+		if err := addColumn(tx, "articles", "summary", "TEXT NOT NULL DEFAULT ''"); err != nil {
+			return err
+		}
+		return addColumn(tx, "articles", "tags", "TEXT NOT NULL DEFAULT ''")
+	}},
+	{version: 3, description: "add category and why_it_matters columns", up: func(tx *sql.Tx) error {
+		if err := addColumn(tx, "articles", "category", "TEXT NOT NULL DEFAULT ''"); err != nil {
+			return err
+		}
+		return addColumn(tx, "articles", "why_it_matters", "TEXT NOT NULL DEFAULT ''")
+	}},
+	{version: 4, description: "add full_summary column", up: func(tx *sql.Tx) error {
+		return addColumn(tx, "articles", "full_summary", "TEXT NOT NULL DEFAULT ''")
+	}},
+	{version: 5, description: "add read column", up: func(tx *sql.Tx) error {
+		return addColumn(tx, "articles", "read", "INTEGER NOT NULL DEFAULT 0")
+	}},
+	{version: 6, description: "create feed_validators table", up: createFeedValidators},
+	{version: 7, description: "create source_health table", up: createSourceHealth},
+	{version: 8, description: "create full-text search index", up: createSearchIndex},
+	{version: 9, description: "add starred column", up: func(tx *sql.Tx) error {
+		if err := addColumn(tx, "articles", "starred", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+			return err
+		}
+		_, err := tx.Exec("CREATE INDEX idx_articles_starred ON articles(starred) WHERE starred = 1")
+		return err
+	}},
+	{version: 10, description: "create reading_queue table", up: createReadingQueue},
+	{version: 11, description: "create article_categories table", up: createArticleCategories},
+	{version: 12, description: "add category_source column", up: func(tx *sql.Tx) error {
+		return addColumn(tx, "articles", "category_source", "TEXT NOT NULL DEFAULT ''")
+	}},
+	{version: 13, description: "create ai_jobs table", up: createAIJobs},
+	{version: 14, description: "create ai_usage table", up: createAIUsage},
+	{version: 15, description: "key the search index on rowid", up: rekeySearchIndex},
+	{version: 16, description: "add backfill_seconds column to feed_validators", up: func(tx *sql.Tx) error {
+		return addColumn(tx, "feed_validators", "backfill_seconds", "INTEGER NOT NULL DEFAULT 0")
+	}},
+}
+
+// SchemaVersion returns the schema version recorded in the database.
+func (c *Cache) SchemaVersion() (int, error) {
+	return schemaVersion(c.writeDB)
+}
+
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func schemaVersion(db queryer) (int, error) {
+	ok, err := hasTable(db, "meta")
+	if err != nil || !ok {
+		return 0, err
+	}
+	var value string
+	err = db.QueryRow("SELECT value FROM meta WHERE key = 'schema_version'").Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version %q: %w", value, err)
+	}
+	return v, nil
+}
+
+// migrate brings the database up to the latest version in list.
+func (c *Cache) migrate(list []migration) error {
+	current, err := schemaVersion(c.writeDB)
+	if err != nil {
+		return err
+	}
+	latest := 0
+	if len(list) > 0 {
+		latest = list[len(list)-1].version
+	}
+	if current > latest {
+		return fmt.Errorf("%w: database is at version %d, this build supports up to %d; upgrade devnews", ErrSchemaTooNew, current, latest)
+	}
+
+	var pending []migration
+	for _, m := range list {
+		if m.version > current {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	for _, m := range pending {
+		if m.destructive {
+			if err := c.backup(current); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, m := range pending {
+		if err := c.apply(m); err != nil {
+			return fmt.Errorf("migrating cache to version %d (%s): %w", m.version, m.description, err)
+		}
+	}
+	return nil
+}
+
+func (c *Cache) apply(m migration) error {
+	tx, err := c.writeDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.up(tx); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO meta (key, value) VALUES ('schema_version', ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value
+	`, strconv.Itoa(m.version))
+	if err != nil {
+		return fmt.Errorf("recording schema version: %w", err)
+	}
+	return tx.Commit()
+}
+
+// backup copies the database to "<path>.v<version>.bak" before a destructive
+// migration. Empty databases are not backed up.
+func (c *Cache) backup(version int) error {
+	ok, err := hasTable(c.writeDB, "articles")
+	if err != nil || !ok {
+		return err
+	}
+	dest := fmt.Sprintf("%s.v%d.bak", c.path, version)
+	if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing old backup: %w", err)
+	}
+	if _, err := c.writeDB.Exec("VACUUM INTO ?", dest); err != nil {
+		return fmt.Errorf("backing up cache to %s: %w", dest, err)
+	}
+	return nil
+}
+
+func hasTable(db queryer, name string) (bool, error) {
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("checking for table %s: %w", name, err)
+	}
+	return n > 0, nil
+}
+
+// addColumn adds a column unless it already exists, so legacy migrations can
+// run against databases that picked the column up before versioning.
+func addColumn(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return fmt.Errorf("reading columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("adding column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+func createBaseTables(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS articles (
+			id          TEXT PRIMARY KEY,
+			source      TEXT NOT NULL,
+			title       TEXT NOT NULL,
+			link        TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			published   DATETIME NOT NULL,
+			fetched_at  DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_articles_published ON articles(published DESC);
+		CREATE INDEX IF NOT EXISTS idx_articles_source ON articles(source);
+
+		CREATE TABLE IF NOT EXISTS meta (
+			key   TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		);
+	`)
+	return err
+}
+
+func createFeedValidators(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS feed_validators (
+			source        TEXT PRIMARY KEY,
+			etag          TEXT NOT NULL DEFAULT '',
+			last_modified TEXT NOT NULL DEFAULT ''
+		);
+	`)
+	return err
+}
+
+func createSourceHealth(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS source_health (
+			source               TEXT PRIMARY KEY,
+			last_success         DATETIME,
+			last_error           TEXT NOT NULL DEFAULT '',
+			last_error_at        DATETIME,
+			consecutive_failures INTEGER NOT NULL DEFAULT 0,
+			item_count           INTEGER NOT NULL DEFAULT 0,
+			latency_ms           INTEGER NOT NULL DEFAULT 0,
+			last_checked         DATETIME NOT NULL
+		);
+	`)
+	return err
+}
+
+// createReadingQueue creates the read-later queue. Queue rows are removed with
+// their article; prune never removes articles that are still waiting to be read.
+func createReadingQueue(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE reading_queue (
+			article_id  TEXT PRIMARY KEY,
+			position    INTEGER NOT NULL,
+			added_at    DATETIME NOT NULL,
+			finished_at DATETIME
+		);
+
+		CREATE TRIGGER reading_queue_article_delete AFTER DELETE ON articles BEGIN
+			DELETE FROM reading_queue WHERE article_id = old.id;
+		END;
+	`)
+	return err
+}
+
+// createArticleCategories creates the table of ranked category labels for
+// each article, seeded with the single category already stored on articles.
+// Labels are removed with their article.
+func createArticleCategories(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE article_categories (
+			article_id TEXT NOT NULL,
+			category   TEXT NOT NULL,
+			score      REAL NOT NULL,
+			rank       INTEGER NOT NULL,
+			PRIMARY KEY (article_id, category)
+		);
+		CREATE INDEX idx_article_categories_category ON article_categories(category);
+
+		INSERT INTO article_categories (article_id, category, score, rank)
+		SELECT id, category, 1, 0 FROM articles WHERE category != '';
+
+		CREATE TRIGGER article_categories_article_delete AFTER DELETE ON articles BEGIN
+			DELETE FROM article_categories WHERE article_id = old.id;
+		END;
+	`)
+	return err
+}
+
+// createAIJobs creates the queue of pending AI requests. Jobs are removed
+// with their article.
+func createAIJobs(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE ai_jobs (
+			article_id TEXT NOT NULL,
+			kind       TEXT NOT NULL,
+			attempts   INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			PRIMARY KEY (article_id, kind)
+		);
+
+		CREATE TRIGGER ai_jobs_article_delete AFTER DELETE ON articles BEGIN
+			DELETE FROM ai_jobs WHERE article_id = old.id;
+		END;
+	`)
+	return err
+}
+
+// createAIUsage creates the log of AI requests and their token usage. Rows
+// are kept when articles are pruned so the spend history stays complete.
+func createAIUsage(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE ai_usage (
+			id            INTEGER PRIMARY KEY,
+			day           TEXT NOT NULL,
+			created_at    DATETIME NOT NULL,
+			provider      TEXT NOT NULL,
+			model         TEXT NOT NULL,
+			purpose       TEXT NOT NULL,
+			input_tokens  INTEGER NOT NULL,
+			output_tokens INTEGER NOT NULL,
+			cost          REAL NOT NULL
+		);
+
+		CREATE INDEX idx_ai_usage_day ON ai_usage(day);
+	`)
+	return err
+}
+
+// rekeySearchIndex replaces the search index created by createSearchIndex with
+// an external-content index over articles keyed by rowid. The old triggers
+// deleted by the UNINDEXED id column, a full scan of the index for every
+// pruned or updated article. Prune rebuilds the index after VACUUM, which
+// may renumber the rowids of the articles table.
+func rekeySearchIndex(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP TRIGGER IF EXISTS articles_fts_insert;
+		DROP TRIGGER IF EXISTS articles_fts_delete;
+		DROP TRIGGER IF EXISTS articles_fts_update;
+		DROP TABLE IF EXISTS articles_fts;
+
+		CREATE VIRTUAL TABLE articles_fts USING fts5(
+			id UNINDEXED, title, description, summary, tags, why_it_matters, full_summary,
+			content = 'articles', content_rowid = 'rowid',
+			tokenize = 'porter unicode61'
+		);
+		INSERT INTO articles_fts (articles_fts) VALUES ('rebuild');
+
+		CREATE TRIGGER articles_fts_insert AFTER INSERT ON articles BEGIN
+			INSERT INTO articles_fts (rowid, id, title, description, summary, tags, why_it_matters, full_summary)
+			VALUES (new.rowid, new.id, new.title, new.description, new.summary, new.tags, new.why_it_matters, new.full_summary);
+		END;
+
+		CREATE TRIGGER articles_fts_delete AFTER DELETE ON articles BEGIN
+			INSERT INTO articles_fts (articles_fts, rowid, id, title, description, summary, tags, why_it_matters, full_summary)
+			VALUES ('delete', old.rowid, old.id, old.title, old.description, old.summary, old.tags, old.why_it_matters, old.full_summary);
+		END;
+
+		CREATE TRIGGER articles_fts_update AFTER UPDATE OF title, description, summary, tags, why_it_matters, full_summary ON articles
+		WHEN old.title IS NOT new.title OR old.description IS NOT new.description
+			OR old.summary IS NOT new.summary OR old.tags IS NOT new.tags
+			OR old.why_it_matters IS NOT new.why_it_matters OR old.full_summary IS NOT new.full_summary
+		BEGIN
+			INSERT INTO articles_fts (articles_fts, rowid, id, title, description, summary, tags, why_it_matters, full_summary)
+			VALUES ('delete', old.rowid, old.id, old.title, old.description, old.summary, old.tags, old.why_it_matters, old.full_summary);
+			INSERT INTO articles_fts (rowid, id, title, description, summary, tags, why_it_matters, full_summary)
+			VALUES (new.rowid, new.id, new.title, new.description, new.summary, new.tags, new.why_it_matters, new.full_summary);
+		END;
+	`)
+	return err
+}
+
+// createSearchIndex creates the FTS5 index over article text and the triggers
+// that keep it in sync with the articles table, backfilling existing rows. The
+// index keys rows by article id rather than rowid because VACUUM may renumber
+// the rowids of the articles table.
+func createSearchIndex(tx *sql.Tx) error {
+	exists, err := hasTable(tx, "articles_fts")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		_, err = tx.Exec(`
+			CREATE VIRTUAL TABLE articles_fts USING fts5(
+				id UNINDEXED, title, description, summary, tags, why_it_matters, full_summary,
+				tokenize = 'porter unicode61'
+			);
+
+			INSERT INTO articles_fts (id, title, description, summary, tags, why_it_matters, full_summary)
+			SELECT id, title, description, summary, tags, why_it_matters, full_summary FROM articles;
+		`)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		CREATE TRIGGER IF NOT EXISTS articles_fts_insert AFTER INSERT ON articles BEGIN
+			INSERT INTO articles_fts (id, title, description, summary, tags, why_it_matters, full_summary)
+			VALUES (new.id, new.title, new.description, new.summary, new.tags, new.why_it_matters, new.full_summary);
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS articles_fts_delete AFTER DELETE ON articles BEGIN
+			DELETE FROM articles_fts WHERE id = old.id;
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS articles_fts_update AFTER UPDATE OF title, description, summary, tags, why_it_matters, full_summary ON articles
+		WHEN old.title IS NOT new.title OR old.description IS NOT new.description
+			OR old.summary IS NOT new.summary OR old.tags IS NOT new.tags
+			OR old.why_it_matters IS NOT new.why_it_matters OR old.full_summary IS NOT new.full_summary
+		BEGIN
+			DELETE FROM articles_fts WHERE id = old.id;
+			INSERT INTO articles_fts (id, title, description, summary, tags, why_it_matters, full_summary)
+			VALUES (new.id, new.title, new.description, new.summary, new.tags, new.why_it_matters, new.full_summary);
+		END;
+	`)
+	return err
+}
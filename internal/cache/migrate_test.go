@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// legacySchemas are the schemas written by releases that predate versioned
+// migrations, oldest first. Each entry is applied on top of the previous ones.
+var legacySchemas = []string{
+	`CREATE TABLE articles (
+		id          TEXT PRIMARY KEY,
+		source      TEXT NOT NULL,
+		title       TEXT NOT NULL,
+		link        TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		published   DATETIME NOT NULL,
+		fetched_at  DATETIME NOT NULL
+	);
+	CREATE INDEX idx_articles_published ON articles(published DESC);
+	CREATE INDEX idx_articles_source ON articles(source);
+	CREATE TABLE meta (key TEXT PRIMARY KEY, value TEXT NOT NULL);
+	INSERT INTO articles (id, source, title, link, description, published, fetched_at)
+	VALUES ('legacy', 'GitHub', 'Legacy kernel post', 'https://g.com', 'From an old release', '2026-01-02 03:04:05+00:00', '2026-01-02 03:04:05+00:00');`,
+	`ALTER TABLE articles ADD COLUMN summary TEXT NOT NULL DEFAULT '';
+	ALTER TABLE articles ADD COLUMN tags TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE articles ADD COLUMN category TEXT NOT NULL DEFAULT '';
+	ALTER TABLE articles ADD COLUMN why_it_matters TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE articles ADD COLUMN full_summary TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE articles ADD COLUMN read INTEGER NOT NULL DEFAULT 0;`,
+	`CREATE TABLE feed_validators (source TEXT PRIMARY KEY, etag TEXT NOT NULL DEFAULT '', last_modified TEXT NOT NULL DEFAULT '');`,
+	`CREATE TABLE source_health (
+		source TEXT PRIMARY KEY, last_success DATETIME, last_error TEXT NOT NULL DEFAULT '',
+		last_error_at DATETIME, consecutive_failures INTEGER NOT NULL DEFAULT 0,
+		item_count INTEGER NOT NULL DEFAULT 0, latency_ms INTEGER NOT NULL DEFAULT 0,
+		last_checked DATETIME NOT NULL
+	);`,
+	`CREATE VIRTUAL TABLE articles_fts USING fts5(
+		id UNINDEXED, title, description, summary, tags, why_it_matters, full_summary,
+		tokenize = 'porter unicode61'
+	);
+	CREATE TRIGGER articles_fts_insert AFTER INSERT ON articles BEGIN
+		INSERT INTO articles_fts (id, title, description, summary, tags, why_it_matters, full_summary)
+		VALUES (new.id, new.title, new.description, new.summary, new.tags, new.why_it_matters, new.full_summary);
+	END;
+	CREATE TRIGGER articles_fts_delete AFTER DELETE ON articles BEGIN
+		DELETE FROM articles_fts WHERE id = old.id;
+	END;
+	CREATE TRIGGER articles_fts_update AFTER UPDATE OF title, description, summary, tags, why_it_matters, full_summary ON articles
+	WHEN old.title IS NOT new.title OR old.description IS NOT new.description
+		OR old.summary IS NOT new.summary OR old.tags IS NOT new.tags
+		OR old.why_it_matters IS NOT new.why_it_matters OR old.full_summary IS NOT new.full_summary
+	BEGIN
+		DELETE FROM articles_fts WHERE id = old.id;
+		INSERT INTO articles_fts (id, title, description, summary, tags, why_it_matters, full_summary)
+		VALUES (new.id, new.title, new.description, new.summary, new.tags, new.why_it_matters, new.full_summary);
+	END;
+	INSERT INTO articles_fts (id, title, description, summary, tags, why_it_matters, full_summary)
+	SELECT id, title, description, summary, tags, why_it_matters, full_summary FROM articles;`,
+}
+
+func latestVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+func writeRawDB(t *testing.T, path string, statements ...string) {
+	t.Helper()
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("opening raw db: %v", err)
+	}
+	defer raw.Close()
+	for _, stmt := range statements {
+		if _, err := raw.Exec(stmt); err != nil {
+			t.Fatalf("writing raw schema: %v", err)
+		}
+	}
+}
+
+func TestMigrateFreshDatabase(t *testing.T) {
+	db := testDB(t)
+	v, err := db.SchemaVersion()
+	if err != nil {
+		t.Fatalf("schema version: %v", err)
+	}
+	if v != latestVersion() {
+		t.Errorf("expected version %d, got %d", latestVersion(), v)
+	}
+}
+
+func TestMigrateFromLegacySchemas(t *testing.T) {
+	for n := 1; n <= len(legacySchemas); n++ {
+		n := n
+		t.Run(fmt.Sprintf("legacy%d", n), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "legacy.db")
+			writeRawDB(t, path, legacySchemas[:n]...)
+
+			db, err := Open(path)
+			if err != nil {
+				t.Fatalf("open legacy schema %d: %v", n, err)
+			}
+			defer db.Close()
+
+			v, err := db.SchemaVersion()
+			if err != nil {
+				t.Fatalf("schema version: %v", err)
+			}
+			if v != latestVersion() {
+				t.Errorf("expected version %d, got %d", latestVersion(), v)
+			}
+
+			// The legacy row survives and the full read/write path works.
+			got, err := db.GetArticles(QueryOpts{Search: "kernel"})
+			if err != nil {
+				t.Fatalf("search: %v", err)
+			}
+			if len(got) != 1 || got[0].ID != "legacy" {
+				t.Fatalf("expected legacy article from search, got %v", articleIDs(got))
+			}
+			if err := db.UpdateArticleSummary("legacy", "Scheduler rewrite", "linux"); err != nil {
+				t.Fatalf("update summary: %v", err)
+			}
+			if err := db.MarkArticleRead("legacy"); err != nil {
+				t.Fatalf("mark read: %v", err)
+			}
+			if err := db.SetFeedValidators("GitHub", FeedValidators{ETag: `"x"`}); err != nil {
+				t.Fatalf("set validators: %v", err)
+			}
+			if err := db.RecordFetch(FetchAttempt{Source: "GitHub", Items: 1}); err != nil {
+				t.Fatalf("record fetch: %v", err)
+			}
+			got, _ = db.GetArticles(QueryOpts{Search: "scheduler"})
+			if len(got) != 1 || !got[0].Read {
+				t.Errorf("expected updated legacy article, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestMigrateIsIdempotentAcrossOpens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	for i := 0; i < 2; i++ {
+		db, err := Open(path)
+		if err != nil {
+			t.Fatalf("open %d: %v", i, err)
+		}
+		if i == 0 {
+			db.UpsertArticles(sampleArticles())
+		}
+		db.Close()
+	}
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer db.Close()
+	got, _ := db.GetArticles(QueryOpts{Search: "search"})
+	if len(got) != 1 {
+		t.Errorf("expected search index intact after reopening, got %d results", len(got))
+	}
+}
+
+func TestMigrateRefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	db.writeDB.Exec("UPDATE meta SET value = '999' WHERE key = 'schema_version'")
+	db.Close()
+
+	_, err = Open(path)
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("expected ErrSchemaTooNew, got %v", err)
+	}
+}
+
+func TestMigrateFailureRollsBack(t *testing.T) {
+	db := testDB(t)
+	before, _ := db.SchemaVersion()
+
+	next := latestVersion() + 1
+	list := append(append([]migration{}, migrations...), migration{
+		version:     next,
+		description: "broken",
+		up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec("CREATE TABLE half_done (id INTEGER)"); err != nil {
+				return err
+			}
+			_, err := tx.Exec("NOT VALID SQL")
+			return err
+		},
+	})
+
+	if err := db.migrate(list); err == nil {
+		t.Fatal("expected migration error")
+	}
+	after, _ := db.SchemaVersion()
+	if after != before {
+		t.Errorf("expected version to stay %d, got %d", before, after)
+	}
+	if ok, _ := hasTable(db.writeDB, "half_done"); ok {
+		t.Error("expected partial migration to be rolled back")
+	}
+}
+
+func TestMigrateBacksUpBeforeDestructiveChange(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	from := latestVersion()
+
+	list := append(append([]migration{}, migrations...),
+		migration{
+			version:     from + 1,
+			description: "drop source_health",
+			destructive: true,
+			up: func(tx *sql.Tx) error {
+				_, err := tx.Exec("DROP TABLE source_health")
+				return err
+			},
+		},
+	)
+	if err := db.migrate(list); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	backupPath := fmt.Sprintf("%s.v%d.bak", db.path, from)
+	if _, err := os.Stat(backupPath); err != nil {
+		t.Fatalf("expected backup at %s: %v", backupPath, err)
+	}
+	backup, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	defer backup.Close()
+	var n int
+	if err := backup.QueryRow("SELECT COUNT(*) FROM articles").Scan(&n); err != nil || n != 3 {
+		t.Errorf("expected 3 articles in backup, got %d (%v)", n, err)
+	}
+	if ok, _ := hasTable(backup, "source_health"); !ok {
+		t.Error("expected backup to predate the destructive migration")
+	}
+}
@@ -16,12 +16,167 @@ type Article struct {
 	WhyItMatters string
 	FullSummary  string
 	Read         bool
+	Starred      bool
+
+	// Labels are the categories the article was classified into, primary
+	// first. Category holds the primary label's category.
+	Labels []Label
+	// CategorySource records how the labels were assigned: one of the
+	// CategorySource constants, or "" for articles not yet classified.
+	CategorySource string
+}
+
+// Ways an article's category labels can be assigned.
+const (
+	CategorySourceKeyword = "keyword"
+	CategorySourceAI      = "ai"
+	// CategorySourceAIFallback marks keyword labels on an article the AI
+	// was asked about but did not place, so it is not sent again.
+	CategorySourceAIFallback = "ai-fallback"
+)
+
+// Label is a category assigned to an article with a confidence score
+// between 0 and 1.
+type Label struct {
+	Category string
+	Score    float64
+}
+
+// InCategory reports whether the article's primary or secondary labels
+// include category. Articles without labels fall back to Category.
+func (a Article) InCategory(category string) bool {
+	if a.Category == category {
+		return true
+	}
+	for _, l := range a.Labels {
+		if l.Category == category {
+			return true
+		}
+	}
+	return false
 }
 
 type QueryOpts struct {
 	Since    time.Time
+	Until    time.Time // exclusive upper bound on published
 	Sources  []string
-	Search   string
+	Search   string // full-text query; results are ranked by relevance
 	Limit    int
-	Category string
+	Category string // primary or secondary category
+	// Unclassified selects articles that have no category yet.
+	Unclassified bool
+
+	// Structured search filters, usually produced by query.Parse.
+	Phrases     []string // exact phrases that must appear
+	Exclude     []string // words or phrases that must not appear
+	Tags        []string // words that must appear in the AI tags
+	SourceNames []string // case-insensitive substrings of the source name; any may match
+	Unread      bool
+	Read        bool // only articles that have been read
+	Starred     bool
+
+	// OrderByDate sorts search results newest first instead of by relevance.
+	OrderByDate bool
+}
+
+// FeedValidators holds the HTTP cache validators returned by a source's feed
+// server, used to make conditional requests on the next refresh.
+type FeedValidators struct {
+	ETag         string
+	LastModified string
+	// Backfill is the window the feed was fetched with. Zero means unknown,
+	// for validators saved before it was recorded.
+	Backfill time.Duration
+}
+
+// FailingThreshold is the number of consecutive failed fetches after which a
+// source is reported as failing.
+const FailingThreshold = 3
+
+// FetchAttempt is the outcome of fetching one source, as recorded by RecordFetch.
+type FetchAttempt struct {
+	Source      string
+	Items       int
+	Latency     time.Duration
+	NotModified bool
+	Err         error
+}
+
+// SourceHealth is the fetch history kept for a single source.
+type SourceHealth struct {
+	Source              string
+	LastSuccess         time.Time
+	LastError           string
+	LastErrorAt         time.Time
+	ConsecutiveFailures int
+	ItemCount           int
+	Latency             time.Duration
+	LastChecked         time.Time
+}
+
+// Failing reports whether the source has failed FailingThreshold times in a row.
+func (h SourceHealth) Failing() bool {
+	return h.ConsecutiveFailures >= FailingThreshold
+}
+
+// QueueItem is an article in the reading queue. Unfinished items are read in
+// Position order; FinishedAt is zero until the item has been read.
+type QueueItem struct {
+	Article    Article
+	Position   int
+	AddedAt    time.Time
+	FinishedAt time.Time
+}
+
+// Finished reports whether the queued article has been read.
+func (q QueueItem) Finished() bool {
+	return !q.FinishedAt.IsZero()
+}
+
+// Kinds of AI job.
+const (
+	AIJobSummarize    = "summarize"
+	AIJobWhyItMatters = "why"
+)
+
+// MaxAIJobAttempts is the number of failed attempts after which an AI job is
+// given up on.
+const MaxAIJobAttempts = 3
+
+// AIJob is an AI request for an article that has not succeeded yet. Jobs stay
+// queued across restarts until they succeed or fail MaxAIJobAttempts times.
+type AIJob struct {
+	Article   Article
+	Kind      string
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+}
+
+// AIUsage is the token usage of one AI request.
+type AIUsage struct {
+	At           time.Time
+	Provider     string
+	Model        string
+	Purpose      string // what the request was for, e.g. "summarize" or "themes"
+	InputTokens  int
+	OutputTokens int
+	Cost         float64 // estimated, in USD
+}
+
+// AIUsageTotal sums the AI requests made on a day, for one purpose and
+// model or, when they are empty, for all of them.
+type AIUsageTotal struct {
+	Day          string // local date, YYYY-MM-DD
+	Purpose      string
+	Model        string
+	Calls        int
+	InputTokens  int
+	OutputTokens int
+	Cost         float64
+}
+
+// Tokens returns the input and output tokens together.
+func (t AIUsageTotal) Tokens() int {
+	return t.InputTokens + t.OutputTokens
 }
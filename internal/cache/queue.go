@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrArticleNotFound is returned when an article reference matches nothing in the cache.
+var ErrArticleNotFound = errors.New("article not found")
+
+// FindArticle looks up an article by exact link or by ID prefix. A prefix
+// that matches more than one article is an error.
+func (c *Cache) FindArticle(ref string) (Article, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return Article{}, ErrArticleNotFound
+	}
+
+	rows, err := c.readDB.Query(
+		"SELECT "+articleColumns+" FROM articles WHERE link = ? OR id LIKE ? ESCAPE '\\' ORDER BY link = ? DESC LIMIT 2",
+		ref, likeEscaper.Replace(ref)+"%", ref,
+	)
+	if err != nil {
+		return Article{}, fmt.Errorf("finding article: %w", err)
+	}
+	defer rows.Close()
+
+	matches, err := scanArticles(rows)
+	if err != nil {
+		return Article{}, err
+	}
+	switch {
+	case len(matches) == 0:
+		return Article{}, fmt.Errorf("%w: %s", ErrArticleNotFound, ref)
+	case len(matches) > 1 && matches[0].Link != ref:
+		return Article{}, fmt.Errorf("%q matches more than one article; use a longer id or the full link", ref)
+	}
+	return matches[0], nil
+}
+
+// Enqueue adds an article to the end of the reading queue. Re-adding a
+// finished article puts it back at the end as unread; adding an article that
+// is already waiting is a no-op.
+func (c *Cache) Enqueue(id string) error {
+	result, err := c.writeDB.Exec(`
+		INSERT INTO reading_queue (article_id, position, added_at)
+		SELECT id, (SELECT COALESCE(MAX(position), 0) + 1 FROM reading_queue), ?
+		FROM articles WHERE id = ?
+		ON CONFLICT(article_id) DO UPDATE SET
+			position = excluded.position,
+			added_at = excluded.added_at,
+			finished_at = NULL
+		WHERE finished_at IS NOT NULL
+	`, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("adding to queue: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		var exists int
+		c.writeDB.QueryRow("SELECT COUNT(*) FROM articles WHERE id = ?", id).Scan(&exists)
+		if exists == 0 {
+			return fmt.Errorf("%w: %s", ErrArticleNotFound, id)
+		}
+	}
+	return nil
+}
+
+// Dequeue removes an article from the reading queue.
+func (c *Cache) Dequeue(id string) error {
+	_, err := c.writeDB.Exec("DELETE FROM reading_queue WHERE article_id = ?", id)
+	return err
+}
+
+// FinishQueued marks a queued article as read, recording when it was finished.
+func (c *Cache) FinishQueued(id string) error {
+	_, err := c.writeDB.Exec("UPDATE reading_queue SET finished_at = ? WHERE article_id = ? AND finished_at IS NULL", time.Now(), id)
+	return err
+}
+
+// MoveQueued swaps an unfinished item with its neighbour: up when delta is
+// negative, down when positive. Moving past either end does nothing.
+func (c *Cache) MoveQueued(id string, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+
+	tx, err := c.writeDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var pos int
+	err = tx.QueryRow("SELECT position FROM reading_queue WHERE article_id = ? AND finished_at IS NULL", id).Scan(&pos)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading queue position: %w", err)
+	}
+
+	neighbour := "SELECT article_id, position FROM reading_queue WHERE finished_at IS NULL AND position > ? ORDER BY position ASC LIMIT 1"
+	if delta < 0 {
+		neighbour = "SELECT article_id, position FROM reading_queue WHERE finished_at IS NULL AND position < ? ORDER BY position DESC LIMIT 1"
+	}
+	var (
+		otherID  string
+		otherPos int
+	)
+	err = tx.QueryRow(neighbour, pos).Scan(&otherID, &otherPos)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading queue position: %w", err)
+	}
+
+	if _, err := tx.Exec("UPDATE reading_queue SET position = ? WHERE article_id = ?", otherPos, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE reading_queue SET position = ? WHERE article_id = ?", pos, otherID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Queue returns the reading queue: unfinished items in reading order, followed
+// by finished items (most recently finished first) when includeFinished is set.
+// A positive limit caps the number of items; unfinished ones come first, so
+// only the oldest finished items are cut.
+func (c *Cache) Queue(includeFinished bool, limit int) ([]QueueItem, error) {
+	query := `
+		SELECT ` + articleColumns + `, q.position, q.added_at, q.finished_at
+		FROM reading_queue q JOIN articles ON articles.id = q.article_id`
+	if !includeFinished {
+		query += " WHERE q.finished_at IS NULL"
+	}
+	query += " ORDER BY q.finished_at IS NOT NULL, q.finished_at DESC, q.position ASC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := c.readDB.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("querying queue: %w", err)
+	}
+	defer rows.Close()
+
+	var items []QueueItem
+	for rows.Next() {
+		var (
+			q        QueueItem
+			a        = &q.Article
+			finished sql.NullTime
+		)
+		if err := rows.Scan(&a.ID, &a.Source, &a.Title, &a.Link, &a.Description, &a.Published, &a.FetchedAt, &a.Summary, &a.Tags, &a.Category, &a.WhyItMatters, &a.FullSummary, &a.Read, &a.Starred, &a.CategorySource, &q.Position, &q.AddedAt, &finished); err != nil {
+			return nil, fmt.Errorf("scanning queue item: %w", err)
+		}
+		q.FinishedAt = finished.Time
+		items = append(items, q)
+	}
+	return items, rows.Err()
+}
+
+// NextQueued returns the first unfinished item in the reading queue, or false
+// when the queue is empty.
+func (c *Cache) NextQueued() (QueueItem, bool, error) {
+	items, err := c.Queue(false, 1)
+	if err != nil || len(items) == 0 {
+		return QueueItem{}, false, err
+	}
+	return items[0], true, nil
+}
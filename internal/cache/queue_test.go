@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func queueIDs(items []QueueItem) []string {
+	ids := make([]string, len(items))
+	for i, q := range items {
+		ids[i] = q.Article.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEnqueueOrderAndFinish(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	for _, id := range []string{"bbb", "aaa", "ccc", "aaa"} {
+		if err := db.Enqueue(id); err != nil {
+			t.Fatalf("enqueue %s: %v", id, err)
+		}
+	}
+	items, err := db.Queue(false, 0)
+	if err != nil {
+		t.Fatalf("queue: %v", err)
+	}
+	if got, want := queueIDs(items), []string{"bbb", "aaa", "ccc"}; !equalIDs(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if items[0].AddedAt.IsZero() || items[0].Finished() {
+		t.Errorf("expected added, unfinished item: %+v", items[0])
+	}
+
+	if err := db.FinishQueued("bbb"); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	next, ok, err := db.NextQueued()
+	if err != nil || !ok || next.Article.ID != "aaa" {
+		t.Errorf("expected aaa next, got %v %v %v", next.Article.ID, ok, err)
+	}
+
+	all, _ := db.Queue(true, 0)
+	if got, want := queueIDs(all), []string{"aaa", "ccc", "bbb"}; !equalIDs(got, want) {
+		t.Fatalf("expected finished items last, got %v", got)
+	}
+	if !all[2].Finished() {
+		t.Error("expected bbb to be finished")
+	}
+
+	// Re-adding a finished article queues it again at the end
+	db.Enqueue("bbb")
+	items, _ = db.Queue(false, 0)
+	if got, want := queueIDs(items), []string{"aaa", "ccc", "bbb"}; !equalIDs(got, want) {
+		t.Errorf("expected re-queued bbb at the end, got %v", got)
+	}
+}
+
+func TestEnqueueUnknownArticle(t *testing.T) {
+	db := testDB(t)
+	if err := db.Enqueue("nope"); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("expected ErrArticleNotFound, got %v", err)
+	}
+}
+
+func TestMoveQueued(t *testing.T) {
+	db := testDB(t)
+	db.UpsertArticles(sampleArticles())
+	for _, id := range []string{"aaa", "bbb", "ccc"} {
+		db.Enqueue(id)
+	}
+
+	if err := db.MoveQueued("ccc", -1); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	items, _ := db.Queue(false, 0)
+	if got, want := queueIDs(items), []string{"aaa", "ccc", "bbb"}; !equalIDs(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// Moving past either end is a no-op
+	db.MoveQueued("aaa", -1)
+	db.MoveQueued("bbb", 1)
+	items, _ = db.Queue(false, 0)
+	if got, want := queueIDs(items), []string{"aaa", "ccc", "bbb"}; !equalIDs(got, want) {
+		t.Fatalf("expected %v unchanged, got %v", want, got)
+	}
+
+	// Finished items are skipped when swapping
+	db.FinishQueued("ccc")
+	db.MoveQueued("bbb", -1)
+	items, _ = db.Queue(false, 0)
+	if got, want := queueIDs(items), []string{"bbb", "aaa"}; !equalIDs(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestDequeue(t *testing.T) {
+	db := testDB(t)
+	db.UpsertArticles(sampleArticles())
+	db.Enqueue("aaa")
+	if err := db.Dequeue("aaa"); err != nil {
+		t.Fatalf("dequeue: %v", err)
+	}
+	items, _ := db.Queue(true, 0)
+	if len(items) != 0 {
+		t.Errorf("expected empty queue, got %v", queueIDs(items))
+	}
+}
+
+func TestPruneKeepsUnreadQueueItems(t *testing.T) {
+	db := testDB(t)
+	now := time.Now()
+	db.UpsertArticles([]Article{
+		{ID: "waiting", Source: "A", Title: "Waiting", Link: "https://a.com/1", Published: now.Add(-72 * time.Hour), FetchedAt: now},
+		{ID: "done", Source: "A", Title: "Done", Link: "https://a.com/2", Published: now.Add(-72 * time.Hour), FetchedAt: now},
+	})
+	db.Enqueue("waiting")
+	db.Enqueue("done")
+	db.FinishQueued("done")
+
+	deleted, err := db.Prune(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected only the finished item pruned, got %d", deleted)
+	}
+	items, _ := db.Queue(true, 0)
+	if got, want := queueIDs(items), []string{"waiting"}; !equalIDs(got, want) {
+		t.Errorf("expected queue row of pruned article removed, got %v", got)
+	}
+}
+
+func TestFindArticle(t *testing.T) {
+	db := testDB(t)
+	now := time.Now()
+	db.UpsertArticles([]Article{
+		{ID: "abc123", Source: "A", Title: "One", Link: "https://a.com/one", Published: now, FetchedAt: now},
+		{ID: "abd456", Source: "A", Title: "Two", Link: "https://a.com/two", Published: now, FetchedAt: now},
+	})
+
+	a, err := db.FindArticle("https://a.com/two")
+	if err != nil || a.ID != "abd456" {
+		t.Errorf("find by link: got %q, %v", a.ID, err)
+	}
+	a, err = db.FindArticle("abc")
+	if err != nil || a.ID != "abc123" {
+		t.Errorf("find by prefix: got %q, %v", a.ID, err)
+	}
+	if _, err := db.FindArticle("ab"); err == nil {
+		t.Error("expected ambiguous prefix to fail")
+	}
+	if _, err := db.FindArticle("zzz"); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("expected ErrArticleNotFound, got %v", err)
+	}
+	if _, err := db.FindArticle("%"); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("expected wildcard to match literally, got %v", err)
+	}
+}
+
+func TestQueueLimitKeepsUnfinished(t *testing.T) {
+	db := testDB(t)
+	if err := db.UpsertArticles(sampleArticles()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	for _, id := range []string{"aaa", "bbb", "ccc"} {
+		db.Enqueue(id)
+	}
+	db.FinishQueued("aaa")
+	time.Sleep(10 * time.Millisecond)
+	db.FinishQueued("bbb")
+
+	items, err := db.Queue(true, 2)
+	if err != nil {
+		t.Fatalf("queue: %v", err)
+	}
+	if got, want := queueIDs(items), []string{"ccc", "bbb"}; !equalIDs(got, want) {
+		t.Errorf("expected the unfinished item and the latest finished one, got %v", got)
+	}
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// usageDay is the local date AI usage at t counts toward.
+func usageDay(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
+
+// RecordAIUsage logs the token usage of an AI request.
+func (c *Cache) RecordAIUsage(u AIUsage) error {
+	_, err := c.writeDB.Exec(`
+		INSERT INTO ai_usage (day, created_at, provider, model, purpose, input_tokens, output_tokens, cost)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, usageDay(u.At), u.At, u.Provider, u.Model, u.Purpose, u.InputTokens, u.OutputTokens, u.Cost)
+	if err != nil {
+		return fmt.Errorf("recording AI usage: %w", err)
+	}
+	return nil
+}
+
+// AIUsageOn returns the AI usage of the local day containing t, over all
+// purposes.
+func (c *Cache) AIUsageOn(t time.Time) (AIUsageTotal, error) {
+	total := AIUsageTotal{Day: usageDay(t)}
+	err := c.readDB.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(input_tokens), 0), COALESCE(SUM(output_tokens), 0), COALESCE(SUM(cost), 0)
+		FROM ai_usage WHERE day = ?
+	`, total.Day).Scan(&total.Calls, &total.InputTokens, &total.OutputTokens, &total.Cost)
+	if err != nil {
+		return AIUsageTotal{}, fmt.Errorf("reading AI usage: %w", err)
+	}
+	return total, nil
+}
+
+// AIUsageSince returns the AI usage of each day from the one containing
+// since onward, by purpose and model, newest day first.
+func (c *Cache) AIUsageSince(since time.Time) ([]AIUsageTotal, error) {
+	rows, err := c.readDB.Query(`
+		SELECT day, purpose, model, COUNT(*), SUM(input_tokens), SUM(output_tokens), SUM(cost)
+		FROM ai_usage WHERE day >= ?
+		GROUP BY day, purpose, model
+		ORDER BY day DESC, purpose ASC, model ASC
+	`, usageDay(since))
+	if err != nil {
+		return nil, fmt.Errorf("querying AI usage: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []AIUsageTotal
+	for rows.Next() {
+		var t AIUsageTotal
+		if err := rows.Scan(&t.Day, &t.Purpose, &t.Model, &t.Calls, &t.InputTokens, &t.OutputTokens, &t.Cost); err != nil {
+			return nil, fmt.Errorf("scanning AI usage: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAIUsage(t *testing.T) {
+	db := testDB(t)
+	today := time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local)
+	yesterday := today.AddDate(0, 0, -1)
+	for _, u := range []AIUsage{
+		{At: yesterday, Provider: "openai", Model: "gpt-4o-mini", Purpose: "summarize", InputTokens: 100, OutputTokens: 20, Cost: 0.001},
+		{At: today, Provider: "openai", Model: "gpt-4o-mini", Purpose: "summarize", InputTokens: 200, OutputTokens: 40, Cost: 0.002},
+		{At: today.Add(time.Hour), Provider: "openai", Model: "gpt-4o-mini", Purpose: "summarize", InputTokens: 300, OutputTokens: 60, Cost: 0.003},
+		{At: today.Add(2 * time.Hour), Provider: "openai", Model: "gpt-4o-mini", Purpose: "themes", InputTokens: 50, OutputTokens: 10, Cost: 0.0005},
+		{At: today.Add(3 * time.Hour), Provider: "ollama", Model: "llama3.2", Purpose: "themes", InputTokens: 70, OutputTokens: 30},
+	} {
+		if err := db.RecordAIUsage(u); err != nil {
+			t.Fatalf("RecordAIUsage: %v", err)
+		}
+	}
+
+	total, err := db.AIUsageOn(today)
+	if err != nil {
+		t.Fatalf("AIUsageOn: %v", err)
+	}
+	if total.Day != "2026-03-04" || total.Calls != 4 || total.Tokens() != 760 {
+		t.Errorf("unexpected total for today: %+v", total)
+	}
+	if empty, _ := db.AIUsageOn(today.AddDate(0, 0, 1)); empty.Calls != 0 || empty.Cost != 0 {
+		t.Errorf("expected no usage tomorrow, got %+v", empty)
+	}
+
+	report, err := db.AIUsageSince(yesterday)
+	if err != nil {
+		t.Fatalf("AIUsageSince: %v", err)
+	}
+	want := []AIUsageTotal{
+		{Day: "2026-03-04", Purpose: "summarize", Model: "gpt-4o-mini", Calls: 2, InputTokens: 500, OutputTokens: 100},
+		{Day: "2026-03-04", Purpose: "themes", Model: "gpt-4o-mini", Calls: 1, InputTokens: 50, OutputTokens: 10},
+		{Day: "2026-03-04", Purpose: "themes", Model: "llama3.2", Calls: 1, InputTokens: 70, OutputTokens: 30},
+		{Day: "2026-03-03", Purpose: "summarize", Model: "gpt-4o-mini", Calls: 1, InputTokens: 100, OutputTokens: 20},
+	}
+	if len(report) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), report)
+	}
+	for i, w := range want {
+		r := report[i]
+		if r.Day != w.Day || r.Purpose != w.Purpose || r.Model != w.Model || r.Calls != w.Calls || r.InputTokens != w.InputTokens || r.OutputTokens != w.OutputTokens {
+			t.Errorf("row %d = %+v, want %+v", i, r, w)
+		}
+	}
+	if report, _ := db.AIUsageSince(today); len(report) != 3 {
+		t.Errorf("expected only today's rows, got %+v", report)
+	}
+}
@@ -2,6 +2,7 @@ package classify
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -19,11 +20,14 @@ const (
 	Platform           Category = "Platform"
 )
 
-// AllCategories returns all valid categories in canonical order.
+// AllCategories returns all valid categories in canonical order: the
+// built-in ones, then any defined with Configure.
 func AllCategories() []Category {
-	return []Category{AIML, Infrastructure, Databases, DistributedSystems, Security, DeveloperTools, Platform}
+	return append([]Category(nil), current().order...)
 }
 
+var builtinCategories = []Category{AIML, Infrastructure, Databases, DistributedSystems, Security, DeveloperTools, Platform}
+
 var categoryKeywords = map[Category][]string{
 	AIML: {
 		"machine learning", "deep learning", "neural", "llm", "gpt", "transformer",
@@ -62,7 +66,8 @@ var categoryKeywords = map[Category][]string{
 	},
 }
 
-// FocusAliases maps short CLI flags to full category names.
+// FocusAliases maps short CLI flags to the built-in category names. Aliases
+// added with Configure are not listed here.
 var FocusAliases = map[string]Category{
 	"infra":       Infrastructure,
 	"ai":          AIML,
@@ -73,39 +78,64 @@ var FocusAliases = map[string]Category{
 	"platform":    Platform,
 }
 
-// ResolveAlias maps a CLI alias to a Category.
+// ResolveAlias maps a CLI alias or a category name (case-insensitive) to a
+// Category.
 func ResolveAlias(alias string) (Category, error) {
-	alias = strings.ToLower(strings.TrimSpace(alias))
-	if cat, ok := FocusAliases[alias]; ok {
+	t := current()
+	if cat, ok := t.resolve(alias); ok {
 		return cat, nil
 	}
-	// Also accept full category names (case-insensitive)
-	for _, cat := range AllCategories() {
-		if strings.EqualFold(string(cat), alias) {
-			return cat, nil
-		}
-	}
-	valid := make([]string, 0, len(FocusAliases))
-	for k := range FocusAliases {
+	valid := make([]string, 0, len(t.aliases))
+	for k := range t.aliases {
 		valid = append(valid, k)
 	}
-	return "", fmt.Errorf("unknown focus %q (valid: %s)", alias, strings.Join(valid, ", "))
+	sort.Strings(valid)
+	return "", fmt.Errorf("unknown focus %q (valid: %s)", strings.ToLower(strings.TrimSpace(alias)), strings.Join(valid, ", "))
 }
 
+// Label is one category an article was classified into. Score is the share
+// of the article's keyword matches that fell in this category, from 0 to 1.
+type Label struct {
+	Category Category
+	Score    float64
+}
+
+const (
+	// maxLabels is the most categories ClassifyAll returns.
+	maxLabels = 3
+
+	// secondaryRatio is how many keyword matches a secondary category needs,
+	// relative to the primary, to be kept as a label.
+	secondaryRatio = 0.5
+)
+
 // Classify determines the category for an article based on title and description.
 // Title keywords are weighted 2x. Returns Platform as default.
 func Classify(title, description string) Category {
+	return ClassifyAll(title, description)[0].Category
+}
+
+// ClassifyAll returns the categories an article belongs to, best first. The
+// first label is the primary category; secondary labels are kept when they
+// match at least half as strongly, up to three labels in all. When nothing
+// matches, the only label is Platform with a score of 0.
+func ClassifyAll(title, description string) []Label {
 	titleTokens := tokenize(title)
 	descTokens := tokenize(description)
 	titleLower := strings.ToLower(title)
 	descLower := strings.ToLower(description)
 
-	var bestCat Category
-	bestScore := 0
+	type scored struct {
+		cat   Category
+		score int
+	}
+	var matches []scored
+	total := 0
 
-	for i, cat := range AllCategories() {
+	t := current()
+	for _, cat := range t.order {
 		score := 0
-		keywords := categoryKeywords[cat]
+		keywords := t.keywords[cat]
 		for _, kw := range keywords {
 			if !strings.Contains(kw, " ") {
 				// Single-word keyword
@@ -129,25 +159,28 @@ func Classify(title, description string) Category {
 				}
 			}
 		}
-		if score > bestScore || (score == bestScore && score > 0 && i < categoryIndex(bestCat)) {
-			bestScore = score
-			bestCat = cat
+		if score > 0 {
+			matches = append(matches, scored{cat, score})
+			total += score
 		}
 	}
 
-	if bestScore == 0 {
-		return Platform
+	if total == 0 {
+		return []Label{{Category: Platform}}
 	}
-	return bestCat
-}
 
-func categoryIndex(cat Category) int {
-	for i, c := range AllCategories() {
-		if c == cat {
-			return i
+	// Stable, so ties keep canonical category order.
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	var labels []Label
+	for _, m := range matches {
+		if len(labels) == maxLabels || float64(m.score) < secondaryRatio*float64(matches[0].score) {
+			break
 		}
+		labels = append(labels, Label{Category: m.cat, Score: float64(m.score) / float64(total)})
 	}
-	return len(AllCategories())
+	return labels
 }
 
 func tokenize(s string) []string {
@@ -66,6 +66,44 @@ func TestClassifyTitleWeightedHigher(t *testing.T) {
 	}
 }
 
+func TestClassifyAllMultiLabel(t *testing.T) {
+	labels := ClassifyAll("Postgres replication on Kubernetes", "")
+	if len(labels) < 2 || labels[0].Category != Databases {
+		t.Fatalf("expected Databases first with secondary labels, got %v", labels)
+	}
+	var hasInfra bool
+	total := 0.0
+	for i, l := range labels {
+		if l.Category == Infrastructure {
+			hasInfra = true
+		}
+		if i > 0 && l.Score > labels[i-1].Score {
+			t.Errorf("labels not ranked by score: %v", labels)
+		}
+		total += l.Score
+	}
+	if !hasInfra {
+		t.Errorf("expected Infrastructure among the labels, got %v", labels)
+	}
+	if total > 1 {
+		t.Errorf("scores should be shares of at most 1, got total %v", total)
+	}
+}
+
+func TestClassifyAllDropsWeakLabels(t *testing.T) {
+	labels := ClassifyAll("Kubernetes cluster upgrades on AWS", "A note on our database")
+	if len(labels) != 1 || labels[0].Category != Infrastructure {
+		t.Errorf("expected only Infrastructure, got %v", labels)
+	}
+}
+
+func TestClassifyAllNoMatch(t *testing.T) {
+	labels := ClassifyAll("Our Year in Review", "")
+	if len(labels) != 1 || labels[0].Category != Platform || labels[0].Score != 0 {
+		t.Errorf("expected a zero-confidence Platform label, got %v", labels)
+	}
+}
+
 func TestResolveAlias(t *testing.T) {
 	tests := []struct {
 		alias    string
@@ -0,0 +1,119 @@
+package classify
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Definition customizes the categories: it adds keywords and aliases to a
+// built-in category, or defines a new one.
+type Definition struct {
+	Name     string   // category name, or an alias of a built-in category
+	Aliases  []string // short names accepted by ResolveAlias
+	Keywords []string // words or phrases that mark an article as this category
+	Replace  bool     // replace a built-in category's keywords instead of adding to them
+}
+
+// taxonomy is the set of categories in use, with their keywords and aliases.
+// A taxonomy is never modified once active; Configure swaps in a new one.
+type taxonomy struct {
+	order    []Category
+	keywords map[Category][]string
+	aliases  map[string]Category
+}
+
+var (
+	taxonomyMu sync.RWMutex
+	active     = builtinTaxonomy()
+)
+
+func current() taxonomy {
+	taxonomyMu.RLock()
+	defer taxonomyMu.RUnlock()
+	return active
+}
+
+func builtinTaxonomy() taxonomy {
+	t := taxonomy{
+		order:    append([]Category(nil), builtinCategories...),
+		keywords: make(map[Category][]string, len(categoryKeywords)),
+		aliases:  make(map[string]Category, len(FocusAliases)),
+	}
+	for cat, kws := range categoryKeywords {
+		t.keywords[cat] = kws
+	}
+	for alias, cat := range FocusAliases {
+		t.aliases[alias] = cat
+	}
+	return t
+}
+
+// resolve looks up a category by alias or by name, case-insensitively.
+func (t taxonomy) resolve(name string) (Category, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if cat, ok := t.aliases[name]; ok {
+		return cat, true
+	}
+	for _, cat := range t.order {
+		if strings.EqualFold(string(cat), name) {
+			return cat, true
+		}
+	}
+	return "", false
+}
+
+// Configure applies category definitions on top of the built-in categories,
+// replacing any earlier configuration; Configure(nil) restores the built-ins.
+// New categories need at least one keyword, and an alias may not already
+// refer to another category. On error the active categories are unchanged.
+func Configure(defs []Definition) error {
+	t := builtinTaxonomy()
+	for _, d := range defs {
+		name := strings.TrimSpace(d.Name)
+		if name == "" {
+			return fmt.Errorf("category: name is required")
+		}
+		keywords := normalizeKeywords(d.Keywords)
+
+		cat, exists := t.resolve(name)
+		switch {
+		case !exists && len(keywords) == 0:
+			return fmt.Errorf("category %q: a new category needs keywords", name)
+		case !exists:
+			cat = Category(name)
+			t.order = append(t.order, cat)
+			t.keywords[cat] = keywords
+		case d.Replace:
+			t.keywords[cat] = keywords
+		default:
+			t.keywords[cat] = append(append([]string(nil), t.keywords[cat]...), keywords...)
+		}
+
+		for _, alias := range d.Aliases {
+			alias = strings.ToLower(strings.TrimSpace(alias))
+			if alias == "" {
+				continue
+			}
+			if other, ok := t.resolve(alias); ok && other != cat {
+				return fmt.Errorf("category %q: alias %q already refers to %s", name, alias, other)
+			}
+			t.aliases[alias] = cat
+		}
+	}
+
+	taxonomyMu.Lock()
+	active = t
+	taxonomyMu.Unlock()
+	return nil
+}
+
+func normalizeKeywords(keywords []string) []string {
+	var out []string
+	for _, kw := range keywords {
+		if kw = strings.ToLower(strings.TrimSpace(kw)); kw != "" {
+			out = append(out, kw)
+		}
+	}
+	return out
+}
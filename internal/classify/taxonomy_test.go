@@ -0,0 +1,86 @@
+package classify
+
+import (
+	"slices"
+	"testing"
+)
+
+func configure(t *testing.T, defs []Definition) {
+	t.Helper()
+	if err := Configure(defs); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { Configure(nil) })
+}
+
+func TestConfigureNewCategory(t *testing.T) {
+	configure(t, []Definition{{
+		Name:     "Observability",
+		Aliases:  []string{"o11y"},
+		Keywords: []string{"OpenTelemetry", "tracing", "distributed tracing"},
+	}})
+
+	if got := Classify("Rolling out OpenTelemetry tracing", ""); got != "Observability" {
+		t.Errorf("expected Observability, got %s", got)
+	}
+	for _, name := range []string{"o11y", "observability"} {
+		if got, err := ResolveAlias(name); err != nil || got != "Observability" {
+			t.Errorf("ResolveAlias(%q) = %q, %v", name, got, err)
+		}
+	}
+	if cats := AllCategories(); len(cats) != 8 || cats[7] != "Observability" {
+		t.Errorf("expected Observability after the built-ins, got %v", cats)
+	}
+}
+
+func TestConfigureExtendAndReplace(t *testing.T) {
+	configure(t, []Definition{
+		{Name: "db", Keywords: []string{"DuckDB"}},
+		{Name: "Security", Keywords: []string{"supply chain"}, Replace: true},
+	})
+
+	if got := Classify("DuckDB internals", ""); got != Databases {
+		t.Errorf("expected added keyword to classify as Databases, got %s", got)
+	}
+	if got := Classify("Postgres internals", ""); got != Databases {
+		t.Errorf("expected built-in keywords to be kept, got %s", got)
+	}
+	if got := Classify("Securing the supply chain", ""); got != Security {
+		t.Errorf("expected replaced keywords to match, got %s", got)
+	}
+	if got := Classify("OAuth token rotation", ""); got == Security {
+		t.Error("expected replaced built-in keywords to be dropped")
+	}
+}
+
+func TestConfigureErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		defs []Definition
+	}{
+		{"missing name", []Definition{{Keywords: []string{"x"}}}},
+		{"new category without keywords", []Definition{{Name: "Frontend"}}},
+		{"alias taken", []Definition{{Name: "Frontend", Keywords: []string{"css"}, Aliases: []string{"db"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Configure(tt.defs); err == nil {
+				t.Error("expected error")
+			}
+			if slices.Contains(AllCategories(), "Frontend") {
+				t.Error("a failed Configure should leave the categories unchanged")
+			}
+		})
+	}
+}
+
+func TestConfigureNilRestoresBuiltins(t *testing.T) {
+	configure(t, []Definition{{Name: "Frontend", Keywords: []string{"css"}}})
+	Configure(nil)
+	if len(AllCategories()) != 7 {
+		t.Errorf("expected the 7 built-in categories, got %v", AllCategories())
+	}
+	if _, err := ResolveAlias("frontend"); err == nil {
+		t.Error("expected custom category to be gone")
+	}
+}
@@ -2,10 +2,15 @@ package config
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/adrg/xdg"
@@ -20,29 +25,163 @@ type Source struct {
 	Type    string `yaml:"type"`
 	URL     string `yaml:"url"`
 	Enabled bool   `yaml:"enabled"`
+
+	// Backfill overrides the global backfill window for this source.
+	Backfill string `yaml:"backfill,omitempty"`
+
+	// Weight scales the briefing score of this source's articles: 2 ranks
+	// them twice as high, 0.5 half as high. Unset means 1.
+	Weight float64 `yaml:"weight,omitempty"`
+}
+
+// DefaultBackfill is how far back feed items are kept when neither the
+// config nor the source sets a backfill window.
+const DefaultBackfill = 7 * 24 * time.Hour
+
+// BackfillWindow returns how old a feed item may be and still be stored:
+// the source's backfill setting, or DefaultBackfill when unset or invalid.
+func (s Source) BackfillWindow() time.Duration {
+	if d, err := parseDuration(s.Backfill); err == nil && d > 0 {
+		return d
+	}
+	return DefaultBackfill
 }
 
 type AIConfig struct {
-	Provider string `yaml:"provider"` // "claude" or "openai"
-	APIKey   string `yaml:"api_key"`
+	Provider string `yaml:"provider"` // one of AIProviders
+	APIKey   string `yaml:"api_key"`  // optional for self-hosted providers
 	Model    string `yaml:"model"`
+	// BaseURL replaces the provider's API base URL, including the version
+	// path, e.g. "http://localhost:8080/v1".
+	BaseURL string `yaml:"base_url,omitempty"`
+	// Classify uses the model to assign article categories instead of
+	// keyword matching.
+	Classify bool `yaml:"classify,omitempty"`
+	// RequestsPerMinute and Concurrency limit the requests sent to the
+	// provider; zero uses the defaults.
+	RequestsPerMinute int `yaml:"requests_per_minute,omitempty"`
+	Concurrency       int `yaml:"concurrency,omitempty"`
+
+	Prefetch *PrefetchConfig `yaml:"prefetch,omitempty"`
+
+	// Prices adds to or overrides the built-in price table used to
+	// estimate spend, keyed by model name.
+	Prices map[string]Price `yaml:"prices,omitempty"`
+	// DailyCap and DailyTokenCap stop AI requests for the rest of the day
+	// once the day's estimated spend in USD or tokens reaches them. Zero
+	// means no cap.
+	DailyCap      float64 `yaml:"daily_cap,omitempty"`
+	DailyTokenCap int     `yaml:"daily_token_cap,omitempty"`
+}
+
+// Price is what a model costs, in USD per million tokens.
+type Price struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+// DefaultModels are the models used by hosted providers when the config
+// names none. Self-hosted providers have no default.
+var DefaultModels = map[string]string{
+	"claude": "claude-haiku-4-5-20251001",
+	"openai": "gpt-4o-mini",
+}
+
+// DefaultPrices is what the default models cost. The config's prices are
+// added to and override it.
+var DefaultPrices = map[string]Price{
+	"claude-haiku-4-5-20251001": {Input: 1, Output: 5},
+	"claude-haiku-4-5":          {Input: 1, Output: 5},
+	"gpt-4o-mini":               {Input: 0.15, Output: 0.60},
+}
+
+// ModelName returns the configured model, or the provider's default.
+func (a *AIConfig) ModelName() string {
+	if a.Model != "" {
+		return a.Model
+	}
+	return DefaultModels[a.Provider]
+}
+
+// PriceOf returns the price of model from the config's prices or
+// DefaultPrices, and whether it has one.
+func (a *AIConfig) PriceOf(model string) (Price, bool) {
+	if p, ok := a.Prices[model]; ok {
+		return p, true
+	}
+	p, ok := DefaultPrices[model]
+	return p, ok
+}
+
+// PrefetchConfig turns on summarizing the newest unread articles in the
+// background after each refresh.
+type PrefetchConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Count   int  `yaml:"count,omitempty"` // articles to summarize; default DefaultPrefetchCount
+	// Budget caps the AI requests per refresh; zero means no cap beyond
+	// two per article.
+	Budget int `yaml:"budget,omitempty"`
 }
 
+// DefaultPrefetchCount is the number of articles prefetched when the config
+// does not say.
+const DefaultPrefetchCount = 10
+
+// CategoryConfig defines a new article category, or extends a built-in one
+// named by its name or alias.
+type CategoryConfig struct {
+	Name     string   `yaml:"name"`
+	Aliases  []string `yaml:"aliases,omitempty"`
+	Keywords []string `yaml:"keywords,omitempty"`
+	// Replace drops a built-in category's keywords in favor of Keywords.
+	Replace bool `yaml:"replace,omitempty"`
+	// Color is the TUI badge color, as "#RRGGBB" or an ANSI color number.
+	Color string `yaml:"color,omitempty"`
+}
+
+// DiversityConfig limits how many briefing cards one source or category may
+// take. Zero means no limit.
+type DiversityConfig struct {
+	MaxPerSource   int `yaml:"max_per_source"`
+	MaxPerCategory int `yaml:"max_per_category"`
+	MinCategories  int `yaml:"min_categories"`
+}
+
+// DefaultDiversity applies when the config has no diversity section.
+var DefaultDiversity = DiversityConfig{MaxPerSource: 2, MinCategories: 2}
+
 type Config struct {
-	RefreshInterval string    `yaml:"refresh_interval"`
-	Retention       string    `yaml:"retention"`
-	BriefSize       int       `yaml:"brief_size,omitempty"`
-	DefaultFocus    string    `yaml:"focus,omitempty"`
-	Theme           string    `yaml:"theme,omitempty"`
-	Sources         []Source  `yaml:"sources"`
-	AI              *AIConfig `yaml:"ai,omitempty"`
+	RefreshInterval string           `yaml:"refresh_interval"`
+	Retention       string           `yaml:"retention"`
+	Backfill        string           `yaml:"backfill,omitempty"`
+	BriefSize       int              `yaml:"brief_size,omitempty"`
+	Diversity       *DiversityConfig `yaml:"diversity,omitempty"`
+	DefaultFocus    FocusList        `yaml:"focus,omitempty"`
+	Categories      []CategoryConfig `yaml:"categories,omitempty"`
+	Theme           string           `yaml:"theme,omitempty"`
+	Sources         []Source         `yaml:"sources"`
+	AI              *AIConfig        `yaml:"ai,omitempty"`
 }
 
-// AIEnabled returns true if AI is configured with a valid API key.
+// AIProviders lists the supported AI providers. "ollama" and
+// "openai-compatible" talk to self-hosted servers and need no API key.
+var AIProviders = []string{"claude", "openai", "ollama", "openai-compatible"}
+
+// NeedsKey reports whether the provider is a hosted API that requires an
+// API key.
+func (a *AIConfig) NeedsKey() bool {
+	return a.Provider != "ollama" && a.Provider != "openai-compatible"
+}
+
+// AIEnabled returns true if AI is configured with a valid API key, or with a
+// self-hosted provider that needs none.
 func (c *Config) AIEnabled() bool {
 	if c.AI == nil {
 		return false
 	}
+	if !c.AI.NeedsKey() {
+		return true
+	}
 	key := c.AI.APIKey
 	if key == "" {
 		key = os.Getenv("DEVNEWS_AI_KEY")
@@ -70,30 +209,51 @@ func (c *Config) RetentionDuration() time.Duration {
 	if c.Retention == "" {
 		return 7 * 24 * time.Hour // default: 7 days
 	}
-	// Support "Nd" day syntax
-	if len(c.Retention) > 1 && c.Retention[len(c.Retention)-1] == 'd' {
-		var days int
-		if _, err := fmt.Sscanf(c.Retention, "%dd", &days); err == nil {
-			return time.Duration(days) * 24 * time.Hour
-		}
-	}
-	d, err := time.ParseDuration(c.Retention)
+	d, err := parseDuration(c.Retention)
 	if err != nil {
 		return 7 * 24 * time.Hour
 	}
 	return d
 }
 
+// parseDuration parses a Go duration or a whole number of days ("90d").
+func parseDuration(s string) (time.Duration, error) {
+	// Support "Nd" day syntax
+	if len(s) > 1 && s[len(s)-1] == 'd' {
+		var days int
+		if _, err := fmt.Sscanf(s, "%dd", &days); err == nil {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+	}
+	return time.ParseDuration(s)
+}
+
+// EnabledSources returns the enabled sources. Sources without their own
+// backfill window inherit the global one.
 func (c *Config) EnabledSources() []Source {
 	var out []Source
 	for _, s := range c.Sources {
 		if s.Enabled {
+			if s.Backfill == "" {
+				s.Backfill = c.Backfill
+			}
 			out = append(out, s)
 		}
 	}
 	return out
 }
 
+// SourceWeights returns the briefing weight of each source that sets one.
+func (c *Config) SourceWeights() map[string]float64 {
+	weights := make(map[string]float64)
+	for _, s := range c.Sources {
+		if s.Weight > 0 {
+			weights[s.Name] = s.Weight
+		}
+	}
+	return weights
+}
+
 func (c *Config) SourceNames() []string {
 	var names []string
 	for _, s := range c.EnabledSources() {
@@ -110,6 +270,28 @@ func (c *Config) GetBriefSize() int {
 	return c.BriefSize
 }
 
+// GetDiversity returns the briefing diversity rules, defaulting to
+// DefaultDiversity.
+func (c *Config) GetDiversity() DiversityConfig {
+	if c.Diversity == nil {
+		return DefaultDiversity
+	}
+	return *c.Diversity
+}
+
+// GetPrefetch returns the prefetch settings with defaults applied. Enabled
+// is false unless AI is configured and prefetch is turned on.
+func (c *Config) GetPrefetch() PrefetchConfig {
+	if !c.AIEnabled() || c.AI.Prefetch == nil || !c.AI.Prefetch.Enabled {
+		return PrefetchConfig{}
+	}
+	p := *c.AI.Prefetch
+	if p.Count <= 0 {
+		p.Count = DefaultPrefetchCount
+	}
+	return p
+}
+
 func DefaultConfigPath() string {
 	return filepath.Join(xdg.ConfigHome, "devnews", "config.yaml")
 }
@@ -198,12 +380,25 @@ func mergeDefaultSources(cfg *Config, defaults *Config) {
 
 // saveConfig loads the config, applies a mutation, and writes it back atomically.
 func saveConfig(mutate func(*Config)) error {
-	path := DefaultConfigPath()
+	return saveConfigTo("", func(cfg *Config) error {
+		mutate(cfg)
+		return nil
+	})
+}
+
+// saveConfigTo is saveConfig for the config file at path, or the default
+// location when path is empty. Nothing is written if mutate returns an error.
+func saveConfigTo(path string, mutate func(*Config) error) error {
+	if path == "" {
+		path = DefaultConfigPath()
+	}
 	cfg, err := Load(path)
 	if err != nil {
 		return err
 	}
-	mutate(cfg)
+	if err := mutate(cfg); err != nil {
+		return err
+	}
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return fmt.Errorf("marshalling config: %w", err)
@@ -224,7 +419,7 @@ func SaveAIKey(provider, apiKey string) error {
 		cfg.AI.Provider = provider
 		cfg.AI.APIKey = apiKey
 		if cfg.AI.Model == "" {
-			cfg.AI.Model = "gpt-4o-mini"
+			cfg.AI.Model = DefaultModels["openai"]
 		}
 	})
 }
@@ -236,6 +431,230 @@ func SaveTheme(themeName string) error {
 	})
 }
 
+// SkippedSource is a source that ImportSources did not add, with the reason.
+type SkippedSource struct {
+	Source Source
+	Reason string
+}
+
+// ImportResult reports what ImportSources changed.
+type ImportResult struct {
+	Added   []Source
+	Skipped []SkippedSource
+}
+
+// MergeSources returns existing with every new source appended, skipping
+// sources whose URL or name (case-insensitive) is already present and sources
+// that would not validate. Sources without a name are named after their host,
+// and an empty type defaults to rss.
+func MergeSources(existing, incoming []Source) ([]Source, ImportResult) {
+	var result ImportResult
+	merged := append([]Source(nil), existing...)
+
+	urls := make(map[string]bool, len(existing))
+	names := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		urls[normalizeURL(s.URL)] = true
+		names[strings.ToLower(s.Name)] = true
+	}
+
+	for _, s := range incoming {
+		s.Name = strings.TrimSpace(s.Name)
+		s.URL = strings.TrimSpace(s.URL)
+		if s.Type == "" {
+			s.Type = "rss"
+		}
+		if s.Name == "" {
+			if u, err := url.Parse(s.URL); err == nil && u.Host != "" {
+				s.Name = strings.TrimPrefix(u.Hostname(), "www.")
+			}
+		}
+		s.Enabled = true
+
+		switch {
+		case urls[normalizeURL(s.URL)]:
+			result.Skipped = append(result.Skipped, SkippedSource{s, "url already configured"})
+			continue
+		case names[strings.ToLower(s.Name)]:
+			result.Skipped = append(result.Skipped, SkippedSource{s, "name already configured"})
+			continue
+		}
+		if err := validate(&Config{Sources: []Source{s}}); err != nil {
+			result.Skipped = append(result.Skipped, SkippedSource{s, err.Error()})
+			continue
+		}
+
+		urls[normalizeURL(s.URL)] = true
+		names[strings.ToLower(s.Name)] = true
+		merged = append(merged, s)
+		result.Added = append(result.Added, s)
+	}
+	return merged, result
+}
+
+// normalizeURL reduces a feed URL to a form where trivially different
+// spellings of the same feed compare equal.
+func normalizeURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return strings.TrimSpace(raw)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.Fragment = ""
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	return u.String()
+}
+
+// ImportSources merges sources into the config file at path (the default
+// location when empty) and writes it back atomically. The file is left
+// untouched when there is nothing new to add.
+func ImportSources(path string, sources []Source) (ImportResult, error) {
+	if path == "" {
+		path = DefaultConfigPath()
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	_, result := MergeSources(cfg.Sources, sources)
+	if len(result.Added) == 0 {
+		return result, nil
+	}
+
+	err = saveConfigTo(path, func(cfg *Config) error {
+		cfg.Sources, _ = MergeSources(cfg.Sources, sources)
+		return nil
+	})
+	return result, err
+}
+
+var (
+	// ErrSourceNotFound is returned when no configured source has the name.
+	ErrSourceNotFound = errors.New("source not found")
+	// ErrBuiltinSource is returned when editing or deleting a default source.
+	// Default sources are merged back in on every load, so they can only be
+	// disabled.
+	ErrBuiltinSource = errors.New("built-in sources can only be disabled")
+)
+
+// IsDefaultSource reports whether name is one of the sources shipped in the
+// embedded default config.
+func IsDefaultSource(name string) bool {
+	defaults, err := loadDefaults()
+	if err != nil {
+		return false
+	}
+	for _, s := range defaults.Sources {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSource appends a source to the config file at path (the default location
+// when empty). It fails if the name or URL is already configured or the
+// source does not validate.
+func AddSource(path string, source Source) (Source, error) {
+	var added Source
+	err := saveConfigTo(path, func(cfg *Config) error {
+		merged, result := MergeSources(cfg.Sources, []Source{source})
+		if len(result.Added) == 0 {
+			return fmt.Errorf("adding %q: %s", result.Skipped[0].Source.Name, result.Skipped[0].Reason)
+		}
+		cfg.Sources = merged
+		added = result.Added[0]
+		return nil
+	})
+	return added, err
+}
+
+// UpdateSource replaces the source called name with source, keeping its
+// enabled state, and its backfill window and weight unless source sets them.
+// Default sources cannot be edited. The cache keys feed state and articles
+// by source name, so callers follow up with Cache.EditSource.
+func UpdateSource(path, name string, source Source) error {
+	if IsDefaultSource(name) {
+		return ErrBuiltinSource
+	}
+	return saveConfigTo(path, func(cfg *Config) error {
+		i := sourceIndex(cfg.Sources, name)
+		if i < 0 {
+			return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+		}
+		source.Name = strings.TrimSpace(source.Name)
+		source.URL = strings.TrimSpace(source.URL)
+		if source.Type == "" {
+			source.Type = cfg.Sources[i].Type
+		}
+		source.Enabled = cfg.Sources[i].Enabled
+		if source.Backfill == "" {
+			source.Backfill = cfg.Sources[i].Backfill
+		}
+		if source.Weight == 0 {
+			source.Weight = cfg.Sources[i].Weight
+		}
+
+		for j, s := range cfg.Sources {
+			if j == i {
+				continue
+			}
+			if strings.EqualFold(s.Name, source.Name) {
+				return fmt.Errorf("updating %q: name already configured", name)
+			}
+			if normalizeURL(s.URL) == normalizeURL(source.URL) {
+				return fmt.Errorf("updating %q: url already configured", name)
+			}
+		}
+		if err := validate(&Config{Sources: []Source{source}}); err != nil {
+			return err
+		}
+		cfg.Sources[i] = source
+		return nil
+	})
+}
+
+// SetSourceEnabled enables or disables the source called name.
+func SetSourceEnabled(path, name string, enabled bool) error {
+	return saveConfigTo(path, func(cfg *Config) error {
+		i := sourceIndex(cfg.Sources, name)
+		if i < 0 {
+			return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+		}
+		cfg.Sources[i].Enabled = enabled
+		return nil
+	})
+}
+
+// RemoveSource deletes the source called name. Default sources cannot be
+// removed; disable them instead.
+func RemoveSource(path, name string) error {
+	if IsDefaultSource(name) {
+		return ErrBuiltinSource
+	}
+	return saveConfigTo(path, func(cfg *Config) error {
+		i := sourceIndex(cfg.Sources, name)
+		if i < 0 {
+			return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+		}
+		cfg.Sources = append(cfg.Sources[:i], cfg.Sources[i+1:]...)
+		return nil
+	})
+}
+
+func sourceIndex(sources []Source, name string) int {
+	for i, s := range sources {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
 func writeDefaults(path string) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
@@ -244,8 +663,94 @@ func writeDefaults(path string) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
+// sourceTypes holds the source types accepted by validate. The feed package
+// registers a type here for every Fetcher it knows about; rss and atom are
+// seeded so configs validate even when feed is not linked in.
+var (
+	sourceTypesMu sync.RWMutex
+	sourceTypes   = map[string]bool{"rss": true, "atom": true}
+)
+
+// RegisterSourceType marks a source type as valid. It is called by
+// feed.Register and should not normally be used directly.
+func RegisterSourceType(sourceType string) {
+	sourceTypesMu.Lock()
+	defer sourceTypesMu.Unlock()
+	sourceTypes[sourceType] = true
+}
+
+// SourceTypes returns the valid source types in sorted order.
+func SourceTypes() []string {
+	sourceTypesMu.RLock()
+	defer sourceTypesMu.RUnlock()
+	types := make([]string, 0, len(sourceTypes))
+	for t := range sourceTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func validSourceType(sourceType string) bool {
+	sourceTypesMu.RLock()
+	defer sourceTypesMu.RUnlock()
+	return sourceTypes[sourceType]
+}
+
+// colorPattern matches the color forms lipgloss accepts: hex and ANSI numbers.
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|#[0-9a-fA-F]{3}|[0-9]{1,3})$`)
+
 func validate(cfg *Config) error {
-	validTypes := map[string]bool{"rss": true, "atom": true}
+	if d := cfg.Diversity; d != nil && (d.MaxPerSource < 0 || d.MaxPerCategory < 0 || d.MinCategories < 0) {
+		return fmt.Errorf("diversity limits must not be negative")
+	}
+	if a := cfg.AI; a != nil && a.BaseURL != "" {
+		u, err := url.Parse(a.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("ai: invalid base_url %q (use an http or https URL)", a.BaseURL)
+		}
+	}
+	if a := cfg.AI; a != nil && !a.NeedsKey() && a.Model == "" {
+		return fmt.Errorf("ai: provider %s needs a model (e.g. model: llama3.2)", a.Provider)
+	}
+	if a := cfg.AI; a != nil && (a.RequestsPerMinute < 0 || a.Concurrency < 0) {
+		return fmt.Errorf("ai: requests_per_minute and concurrency must not be negative")
+	}
+	if a := cfg.AI; a != nil && a.Prefetch != nil && (a.Prefetch.Count < 0 || a.Prefetch.Budget < 0) {
+		return fmt.Errorf("ai.prefetch: count and budget must not be negative")
+	}
+	if a := cfg.AI; a != nil && (a.DailyCap < 0 || a.DailyTokenCap < 0) {
+		return fmt.Errorf("ai: daily_cap and daily_token_cap must not be negative")
+	}
+	if a := cfg.AI; a != nil && a.DailyCap > 0 {
+		// Spend on an unpriced model counts as $0, so the cap would never
+		// be reached.
+		if model := a.ModelName(); model != "" {
+			if _, ok := a.PriceOf(model); !ok {
+				return fmt.Errorf("ai: daily_cap needs a price for model %q; add it under ai.prices or use daily_token_cap", model)
+			}
+		}
+	}
+	if a := cfg.AI; a != nil {
+		for model, p := range a.Prices {
+			if p.Input < 0 || p.Output < 0 {
+				return fmt.Errorf("ai.prices: %s: prices must not be negative", model)
+			}
+		}
+	}
+	for i, c := range cfg.Categories {
+		if strings.TrimSpace(c.Name) == "" {
+			return fmt.Errorf("category %d: name is required", i)
+		}
+		if c.Color != "" && !colorPattern.MatchString(c.Color) {
+			return fmt.Errorf("category %q: invalid color %q (use #RRGGBB or an ANSI color number)", c.Name, c.Color)
+		}
+	}
+	if cfg.Backfill != "" {
+		if _, err := parseDuration(cfg.Backfill); err != nil {
+			return fmt.Errorf("invalid backfill %q: %w", cfg.Backfill, err)
+		}
+	}
 	for i, s := range cfg.Sources {
 		if s.Name == "" {
 			return fmt.Errorf("source %d: name is required", i)
@@ -260,8 +765,16 @@ func validate(cfg *Config) error {
 		if u.Scheme != "http" && u.Scheme != "https" {
 			return fmt.Errorf("source %q: url scheme must be http or https, got %q", s.Name, u.Scheme)
 		}
-		if !validTypes[s.Type] {
-			return fmt.Errorf("source %q: unknown type %q (valid: rss, atom)", s.Name, s.Type)
+		if !validSourceType(s.Type) {
+			return fmt.Errorf("source %q: unknown type %q (valid: %s)", s.Name, s.Type, strings.Join(SourceTypes(), ", "))
+		}
+		if s.Backfill != "" {
+			if _, err := parseDuration(s.Backfill); err != nil {
+				return fmt.Errorf("source %q: invalid backfill %q: %w", s.Name, s.Backfill, err)
+			}
+		}
+		if s.Weight < 0 {
+			return fmt.Errorf("source %q: weight must not be negative, got %g", s.Name, s.Weight)
 		}
 	}
 	return nil
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadDefaults(t *testing.T) {
@@ -239,3 +241,375 @@ func TestValidateAcceptsHTTP(t *testing.T) {
 		t.Errorf("unexpected error for http URL: %v", err)
 	}
 }
+
+func TestValidateRegisteredType(t *testing.T) {
+	cfg := &Config{Sources: []Source{{Name: "Wiki", Type: "confluence", URL: "https://wiki.example.com"}}}
+	if err := validate(cfg); err == nil {
+		t.Fatal("expected error before type is registered")
+	}
+
+	RegisterSourceType("confluence")
+	if err := validate(cfg); err != nil {
+		t.Errorf("unexpected error for registered type: %v", err)
+	}
+}
+
+func TestMergeSources(t *testing.T) {
+	existing := []Source{
+		{Name: "Stripe", Type: "rss", URL: "https://stripe.com/blog/feed.rss", Enabled: true},
+	}
+	incoming := []Source{
+		{Name: "Stripe Blog", URL: "http://www.stripe.com/blog/feed.rss/"}, // same URL, different spelling
+		{Name: "stripe", URL: "https://other.com/feed"},                    // same name
+		{Name: "Lobsters", URL: "https://lobste.rs/rss"},
+		{Name: "Lobsters again", URL: "https://lobste.rs/rss"}, // duplicate within the import
+		{URL: "https://jvns.ca/atom.xml", Type: "atom"},        // unnamed
+		{Name: "Gopher", URL: "gopher://example.com/feed"},     // invalid scheme
+	}
+
+	merged, result := MergeSources(existing, incoming)
+
+	if len(result.Added) != 2 {
+		t.Fatalf("expected 2 added, got %+v", result.Added)
+	}
+	if result.Added[0].Name != "Lobsters" || result.Added[0].Type != "rss" || !result.Added[0].Enabled {
+		t.Errorf("unexpected first added source: %+v", result.Added[0])
+	}
+	if result.Added[1].Name != "jvns.ca" || result.Added[1].Type != "atom" {
+		t.Errorf("expected unnamed source named after its host, got %+v", result.Added[1])
+	}
+	if len(result.Skipped) != 4 {
+		t.Errorf("expected 4 skipped, got %+v", result.Skipped)
+	}
+	if len(merged) != 3 || merged[0].Name != "Stripe" {
+		t.Errorf("expected existing sources kept first, got %+v", merged)
+	}
+}
+
+func TestImportSources(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	content := `refresh_interval: 2h
+sources:
+  - name: Test
+    type: rss
+    url: https://example.com/feed
+    enabled: true
+`
+	if err := os.WriteFile(cfgPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	result, err := ImportSources(cfgPath, []Source{
+		{Name: "Test", URL: "https://example.com/feed"},
+		{Name: "Lobsters", URL: "https://lobste.rs/rss"},
+	})
+	if err != nil {
+		t.Fatalf("ImportSources: %v", err)
+	}
+	if len(result.Added) != 1 || len(result.Skipped) != 1 {
+		t.Fatalf("expected 1 added and 1 skipped, got %+v", result)
+	}
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.RefreshInterval != "2h" {
+		t.Errorf("expected other settings preserved, got %q", cfg.RefreshInterval)
+	}
+	found := false
+	for _, s := range cfg.Sources {
+		if s.Name == "Lobsters" && s.Enabled {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected Lobsters in saved config")
+	}
+	if _, err := os.Stat(cfgPath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("expected temp file to be renamed away")
+	}
+
+	// Importing the same sources again leaves the file alone
+	before, _ := os.ReadFile(cfgPath)
+	result, err = ImportSources(cfgPath, []Source{{Name: "Lobsters", URL: "https://lobste.rs/rss"}})
+	if err != nil || len(result.Added) != 0 {
+		t.Fatalf("expected nothing added, got %+v, %v", result, err)
+	}
+	after, _ := os.ReadFile(cfgPath)
+	if string(before) != string(after) {
+		t.Error("expected config file unchanged when nothing is imported")
+	}
+}
+
+func TestManageSources(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte("sources: []\n"), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	added, err := AddSource(cfgPath, Source{Name: " Lobsters ", URL: "https://lobste.rs/rss"})
+	if err != nil {
+		t.Fatalf("AddSource: %v", err)
+	}
+	if added.Name != "Lobsters" || added.Type != "rss" || !added.Enabled {
+		t.Errorf("unexpected added source: %+v", added)
+	}
+	if _, err := AddSource(cfgPath, Source{Name: "Dup", URL: "https://lobste.rs/rss/"}); err == nil {
+		t.Error("expected duplicate URL to be rejected")
+	}
+
+	if err := SetSourceEnabled(cfgPath, "Lobsters", false); err != nil {
+		t.Fatalf("SetSourceEnabled: %v", err)
+	}
+	if err := UpdateSource(cfgPath, "Lobsters", Source{Name: "Lobste.rs", URL: "https://lobste.rs/newest.rss"}); err != nil {
+		t.Fatalf("UpdateSource: %v", err)
+	}
+	if err := UpdateSource(cfgPath, "Missing", Source{Name: "x", URL: "https://x.com"}); !errors.Is(err, ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	i := sourceIndex(cfg.Sources, "Lobste.rs")
+	if i < 0 {
+		t.Fatal("expected renamed source in config")
+	}
+	if s := cfg.Sources[i]; s.URL != "https://lobste.rs/newest.rss" || s.Type != "rss" || s.Enabled {
+		t.Errorf("expected URL updated with type and disabled state kept, got %+v", s)
+	}
+
+	if err := RemoveSource(cfgPath, "Lobste.rs"); err != nil {
+		t.Fatalf("RemoveSource: %v", err)
+	}
+	cfg, _ = Load(cfgPath)
+	if sourceIndex(cfg.Sources, "Lobste.rs") >= 0 {
+		t.Error("expected source removed")
+	}
+}
+
+func TestBuiltinSourcesCanOnlyBeDisabled(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte("sources: []\n"), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	defaults, _ := loadDefaults()
+	name := defaults.Sources[0].Name
+	if !IsDefaultSource(name) {
+		t.Fatalf("expected %q to be a default source", name)
+	}
+
+	if err := RemoveSource(cfgPath, name); !errors.Is(err, ErrBuiltinSource) {
+		t.Errorf("expected ErrBuiltinSource on remove, got %v", err)
+	}
+	if err := UpdateSource(cfgPath, name, Source{Name: name, URL: "https://example.com/feed"}); !errors.Is(err, ErrBuiltinSource) {
+		t.Errorf("expected ErrBuiltinSource on update, got %v", err)
+	}
+	if err := SetSourceEnabled(cfgPath, name, false); err != nil {
+		t.Fatalf("SetSourceEnabled: %v", err)
+	}
+
+	cfg, _ := Load(cfgPath)
+	if i := sourceIndex(cfg.Sources, name); i < 0 || cfg.Sources[i].Enabled {
+		t.Errorf("expected %q to stay configured but disabled", name)
+	}
+}
+
+func TestBackfillWindow(t *testing.T) {
+	cfg := &Config{
+		Backfill: "30d",
+		Sources: []Source{
+			{Name: "A", Enabled: true},
+			{Name: "B", Enabled: true, Backfill: "90d"},
+		},
+	}
+	got := cfg.EnabledSources()
+	if w := got[0].BackfillWindow(); w != 30*24*time.Hour {
+		t.Errorf("expected A to inherit the global 30d window, got %v", w)
+	}
+	if w := got[1].BackfillWindow(); w != 90*24*time.Hour {
+		t.Errorf("expected B to keep its own 90d window, got %v", w)
+	}
+	if cfg.Sources[0].Backfill != "" {
+		t.Error("expected EnabledSources not to modify the config")
+	}
+	if w := (Source{}).BackfillWindow(); w != DefaultBackfill {
+		t.Errorf("expected default window, got %v", w)
+	}
+	if w := (Source{Backfill: "36h"}).BackfillWindow(); w != 36*time.Hour {
+		t.Errorf("expected Go duration syntax, got %v", w)
+	}
+}
+
+func TestValidateInvalidBackfill(t *testing.T) {
+	if err := validate(&Config{Backfill: "forever"}); err == nil {
+		t.Error("expected error for invalid global backfill")
+	}
+	cfg := &Config{Sources: []Source{{Name: "A", Type: "rss", URL: "https://a.com/feed", Backfill: "soon"}}}
+	if err := validate(cfg); err == nil {
+		t.Error("expected error for invalid source backfill")
+	}
+}
+
+func TestSourceWeights(t *testing.T) {
+	cfg := &Config{Sources: []Source{
+		{Name: "A", Type: "rss", URL: "https://a.com/feed", Weight: 2},
+		{Name: "B", Type: "rss", URL: "https://b.com/feed"},
+	}}
+	w := cfg.SourceWeights()
+	if len(w) != 1 || w["A"] != 2 {
+		t.Errorf("SourceWeights() = %v, want map[A:2]", w)
+	}
+
+	cfg.Sources[1].Weight = -1
+	if err := validate(cfg); err == nil {
+		t.Error("expected error for negative weight")
+	}
+}
+
+func TestGetDiversity(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetDiversity(); got != DefaultDiversity {
+		t.Errorf("GetDiversity() = %+v, want defaults %+v", got, DefaultDiversity)
+	}
+	cfg.Diversity = &DiversityConfig{MaxPerCategory: 2}
+	if got := cfg.GetDiversity(); got != (DiversityConfig{MaxPerCategory: 2}) {
+		t.Errorf("GetDiversity() = %+v, want the configured rules", got)
+	}
+	cfg.Diversity.MaxPerSource = -1
+	if err := validate(cfg); err == nil {
+		t.Error("expected error for negative diversity limit")
+	}
+}
+
+func TestGetPrefetch(t *testing.T) {
+	cfg := &Config{AI: &AIConfig{Provider: "claude", APIKey: "key", Prefetch: &PrefetchConfig{Enabled: true}}}
+	if got := cfg.GetPrefetch(); !got.Enabled || got.Count != DefaultPrefetchCount || got.Budget != 0 {
+		t.Errorf("GetPrefetch() = %+v, want enabled with the default count", got)
+	}
+	cfg.AI.Prefetch = &PrefetchConfig{Enabled: true, Count: 5, Budget: 8}
+	if got := cfg.GetPrefetch(); got != *cfg.AI.Prefetch {
+		t.Errorf("GetPrefetch() = %+v, want the configured settings", got)
+	}
+	cfg.AI.APIKey = ""
+	t.Setenv("DEVNEWS_AI_KEY", "")
+	if cfg.GetPrefetch().Enabled {
+		t.Error("expected prefetch off without an API key")
+	}
+	cfg.AI.Prefetch.Budget = -1
+	if err := validate(cfg); err == nil {
+		t.Error("expected error for negative budget")
+	}
+}
+
+func TestAIEnabledSelfHosted(t *testing.T) {
+	t.Setenv("DEVNEWS_AI_KEY", "")
+	for provider, want := range map[string]bool{"claude": false, "openai": false, "ollama": true, "openai-compatible": true} {
+		cfg := &Config{AI: &AIConfig{Provider: provider}}
+		if got := cfg.AIEnabled(); got != want {
+			t.Errorf("%s without a key: AIEnabled() = %v, want %v", provider, got, want)
+		}
+	}
+}
+
+func TestValidateAIBaseURL(t *testing.T) {
+	cfg := &Config{AI: &AIConfig{Provider: "ollama", Model: "llama3.2", BaseURL: "http://localhost:11434/v1"}}
+	if err := validate(cfg); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, bad := range []string{"localhost:11434", "ftp://example.com/v1", "http://"} {
+		cfg.AI.BaseURL = bad
+		if err := validate(cfg); err == nil {
+			t.Errorf("expected error for base_url %q", bad)
+		}
+	}
+}
+
+func TestValidateSelfHostedNeedsModel(t *testing.T) {
+	for _, provider := range []string{"ollama", "openai-compatible"} {
+		cfg := &Config{AI: &AIConfig{Provider: provider}}
+		if err := validate(cfg); err == nil {
+			t.Errorf("%s: expected error without a model", provider)
+		}
+	}
+	cfg := &Config{AI: &AIConfig{Provider: "claude"}}
+	if err := validate(cfg); err != nil {
+		t.Errorf("claude uses a default model, got %v", err)
+	}
+}
+
+func TestValidateAILimits(t *testing.T) {
+	cfg := &Config{AI: &AIConfig{RequestsPerMinute: 20, Concurrency: 2}}
+	if err := validate(cfg); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.AI.RequestsPerMinute = -1
+	if err := validate(cfg); err == nil {
+		t.Error("expected error for negative requests_per_minute")
+	}
+}
+
+func TestValidateAISpend(t *testing.T) {
+	cfg := &Config{AI: &AIConfig{
+		DailyCap:      0.5,
+		DailyTokenCap: 100000,
+		Prices:        map[string]Price{"llama3.2": {Input: 0, Output: 0}},
+	}}
+	if err := validate(cfg); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	cfg.AI.DailyCap = -1
+	if err := validate(cfg); err == nil {
+		t.Error("expected error for negative daily_cap")
+	}
+	cfg.AI.DailyCap = 0
+	cfg.AI.Prices["gpt-x"] = Price{Input: -1}
+	if err := validate(cfg); err == nil {
+		t.Error("expected error for negative price")
+	}
+}
+
+func TestValidateDailyCapNeedsPrice(t *testing.T) {
+	tests := []struct {
+		ai   AIConfig
+		fail bool
+	}{
+		{AIConfig{Provider: "claude", DailyCap: 1}, false}, // default model is priced
+		{AIConfig{Provider: "openai", Model: "gpt-5", DailyCap: 1}, true},
+		{AIConfig{Provider: "openai", Model: "gpt-5", DailyCap: 1, Prices: map[string]Price{"gpt-5": {Input: 1, Output: 8}}}, false},
+		{AIConfig{Provider: "ollama", Model: "llama3.2", DailyCap: 1}, true},
+		{AIConfig{Provider: "ollama", Model: "llama3.2", DailyTokenCap: 1000}, false},
+	}
+	for _, tt := range tests {
+		ai := tt.ai
+		err := validate(&Config{AI: &ai})
+		if (err != nil) != tt.fail {
+			t.Errorf("validate(%+v) = %v, want failure %v", tt.ai, err, tt.fail)
+		}
+	}
+}
+
+func TestValidateCategories(t *testing.T) {
+	for _, c := range []CategoryConfig{
+		{Name: "Observability", Color: "#FFA500"},
+		{Name: "Frontend", Color: "212"},
+		{Name: "db"},
+	} {
+		if err := validate(&Config{Categories: []CategoryConfig{c}}); err != nil {
+			t.Errorf("validate(%+v): %v", c, err)
+		}
+	}
+	for _, c := range []CategoryConfig{
+		{Name: " "},
+		{Name: "Frontend", Color: "orange"},
+	} {
+		if err := validate(&Config{Categories: []CategoryConfig{c}}); err == nil {
+			t.Errorf("validate(%+v): expected error", c)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FocusEntry is one category in the briefing focus. Included categories
+// boost matching articles by Weight; excluded ones are left out entirely.
+type FocusEntry struct {
+	Category string
+	Weight   float64 // 1 when not given; unused when Exclude is set
+	Exclude  bool
+}
+
+func (e FocusEntry) String() string {
+	switch {
+	case e.Exclude:
+		return "!" + e.Category
+	case e.Weight == 1:
+		return e.Category
+	default:
+		return e.Category + ":" + strconv.FormatFloat(e.Weight, 'g', -1, 64)
+	}
+}
+
+// FocusList is the briefing focus. In YAML it can be a single category, a
+// list or a map of weights:
+//
+//	focus: db
+//	focus: [db: 2, security, "!ai"]
+//	focus: {db: 2, security: 1}
+//
+// An unquoted !ai list item is read as an exclusion too, although YAML would
+// normally treat it as a tag.
+type FocusList []FocusEntry
+
+// ParseFocus parses the command-line form of a focus list: comma-separated
+// categories, each optionally weighted with ":N" or excluded with a leading
+// "!", as in "db:2,security,!ai".
+func ParseFocus(s string) (FocusList, error) {
+	var list FocusList
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		e, err := parseFocusEntry(part)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
+
+func parseFocusEntry(s string) (FocusEntry, error) {
+	s = strings.TrimSpace(s)
+	e := FocusEntry{Weight: 1}
+	if rest, ok := strings.CutPrefix(s, "!"); ok {
+		e.Exclude = true
+		s = rest
+	}
+	if name, weight, ok := strings.Cut(s, ":"); ok {
+		if e.Exclude {
+			return FocusEntry{}, fmt.Errorf("focus %q: an excluded category cannot have a weight", "!"+s)
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil {
+			return FocusEntry{}, fmt.Errorf("focus %q: invalid weight %q", s, weight)
+		}
+		e.Weight = w
+		s = name
+	}
+	e.Category = strings.TrimSpace(s)
+	return e, e.validate()
+}
+
+func (e FocusEntry) validate() error {
+	if e.Category == "" {
+		return fmt.Errorf("focus: empty category")
+	}
+	if !e.Exclude && e.Weight <= 0 {
+		return fmt.Errorf("focus %q: weight must be positive, got %g", e.Category, e.Weight)
+	}
+	return nil
+}
+
+func (f FocusList) String() string {
+	parts := make([]string, len(f))
+	for i, e := range f {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// UnmarshalYAML accepts the forms described on FocusList.
+func (f *FocusList) UnmarshalYAML(node *yaml.Node) error {
+	var list FocusList
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if excluded, ok := excludeTag(node); ok {
+			list = FocusList{excluded}
+			break
+		}
+		parsed, err := ParseFocus(node.Value)
+		if err != nil {
+			return err
+		}
+		list = parsed
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			switch item.Kind {
+			case yaml.ScalarNode:
+				if excluded, ok := excludeTag(item); ok {
+					list = append(list, excluded)
+					continue
+				}
+				e, err := parseFocusEntry(item.Value)
+				if err != nil {
+					return err
+				}
+				list = append(list, e)
+			case yaml.MappingNode:
+				entries, err := focusMap(item)
+				if err != nil {
+					return err
+				}
+				list = append(list, entries...)
+			default:
+				return fmt.Errorf("line %d: focus items must be categories or category: weight", item.Line)
+			}
+		}
+	case yaml.MappingNode:
+		entries, err := focusMap(node)
+		if err != nil {
+			return err
+		}
+		list = entries
+	default:
+		return fmt.Errorf("line %d: focus must be a category, a list or a map", node.Line)
+	}
+	*f = list
+	return nil
+}
+
+// excludeTag reads an unquoted "!ai", which YAML parses as an empty scalar
+// tagged !ai.
+func excludeTag(node *yaml.Node) (FocusEntry, bool) {
+	if node.Value != "" || !strings.HasPrefix(node.Tag, "!") || strings.HasPrefix(node.Tag, "!!") {
+		return FocusEntry{}, false
+	}
+	return FocusEntry{Category: node.Tag[1:], Weight: 1, Exclude: true}, true
+}
+
+// focusMap reads "category: weight" pairs.
+func focusMap(node *yaml.Node) (FocusList, error) {
+	var list FocusList
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		e, err := parseFocusEntry(key.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !e.Exclude {
+			if err := value.Decode(&e.Weight); err != nil {
+				return nil, fmt.Errorf("line %d: focus %q: weight must be a number", value.Line, e.Category)
+			}
+			if err := e.validate(); err != nil {
+				return nil, err
+			}
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
+
+// MarshalYAML writes a single unweighted category as a plain string, and
+// anything else as a list with weighted categories as one-key maps.
+func (f FocusList) MarshalYAML() (interface{}, error) {
+	if len(f) == 1 && !f[0].Exclude && f[0].Weight == 1 {
+		return f[0].Category, nil
+	}
+	items := make([]interface{}, len(f))
+	for i, e := range f {
+		if e.Exclude || e.Weight == 1 {
+			items[i] = e.String()
+		} else {
+			items[i] = map[string]float64{e.Category: e.Weight}
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseFocus(t *testing.T) {
+	got, err := ParseFocus("db:2, security,!ai")
+	if err != nil {
+		t.Fatalf("ParseFocus: %v", err)
+	}
+	want := FocusList{
+		{Category: "db", Weight: 2},
+		{Category: "security", Weight: 1},
+		{Category: "ai", Weight: 1, Exclude: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFocus() = %+v, want %+v", got, want)
+	}
+	if got.String() != "db:2,security,!ai" {
+		t.Errorf("String() = %q", got.String())
+	}
+
+	for _, bad := range []string{"db:x", "db:0", "db:-1", "!ai:2", "!", ":2"} {
+		if _, err := ParseFocus(bad); err == nil {
+			t.Errorf("ParseFocus(%q): expected error", bad)
+		}
+	}
+}
+
+func TestFocusYAML(t *testing.T) {
+	want := FocusList{
+		{Category: "db", Weight: 2},
+		{Category: "security", Weight: 1},
+		{Category: "ai", Weight: 1, Exclude: true},
+	}
+	tests := []struct {
+		name string
+		yaml string
+		want FocusList
+	}{
+		{"single category", "focus: db", FocusList{{Category: "db", Weight: 1}}},
+		{"flow list", `focus: [db: 2, security, "!ai"]`, want},
+		{"block list with unquoted exclusion", "focus:\n  - db: 2\n  - security\n  - !ai\n", want},
+		{"map", "focus: {db: 2, security: 1}", want[:2]},
+		{"string", "focus: db:2,security,!ai", want},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			if err := yaml.Unmarshal([]byte(tt.yaml), &cfg); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(cfg.DefaultFocus, tt.want) {
+				t.Errorf("focus = %+v, want %+v", cfg.DefaultFocus, tt.want)
+			}
+		})
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal([]byte("focus: {db: many}"), &cfg); err == nil {
+		t.Error("expected error for non-numeric weight")
+	}
+}
+
+func TestFocusYAMLRoundTrip(t *testing.T) {
+	for _, focus := range []FocusList{
+		{{Category: "db", Weight: 1}},
+		{{Category: "db", Weight: 2}, {Category: "security", Weight: 1}, {Category: "ai", Weight: 1, Exclude: true}},
+	} {
+		data, err := yaml.Marshal(&Config{DefaultFocus: focus})
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		var cfg Config
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			t.Fatalf("Unmarshal: %v\n%s", err, data)
+		}
+		if !reflect.DeepEqual(cfg.DefaultFocus, focus) {
+			t.Errorf("round trip = %+v, want %+v\n%s", cfg.DefaultFocus, focus, data)
+		}
+	}
+
+	data, _ := yaml.Marshal(&Config{DefaultFocus: FocusList{{Category: "db", Weight: 1}}})
+	if !strings.Contains(string(data), "focus: db\n") {
+		t.Errorf("expected a single category to be written as a string:\n%s", data)
+	}
+}
@@ -0,0 +1,188 @@
+// Package export writes cached articles in formats suitable for sharing
+// outside devnews.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// Format is an export output format.
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	JSON     Format = "json"
+	CSV      Format = "csv"
+)
+
+// Formats returns the supported formats.
+func Formats() []Format {
+	return []Format{Markdown, JSON, CSV}
+}
+
+// ParseFormat resolves a format name, accepting "md" for Markdown.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "markdown", "md":
+		return Markdown, nil
+	case "json":
+		return JSON, nil
+	case "csv":
+		return CSV, nil
+	}
+	names := make([]string, len(Formats()))
+	for i, f := range Formats() {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown format %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// Write encodes articles to w in the given format.
+func Write(w io.Writer, format Format, articles []cache.Article) error {
+	switch format {
+	case Markdown:
+		return writeMarkdown(w, articles)
+	case JSON:
+		return writeJSON(w, articles)
+	case CSV:
+		return writeCSV(w, articles)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// record is the flat representation shared by the JSON and CSV encoders.
+type record struct {
+	ID           string    `json:"id"`
+	Source       string    `json:"source"`
+	Title        string    `json:"title"`
+	Link         string    `json:"link"`
+	Published    time.Time `json:"published"`
+	Category     string    `json:"category,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Summary      string    `json:"summary,omitempty"`
+	WhyItMatters string    `json:"why_it_matters,omitempty"`
+	FullSummary  string    `json:"full_summary,omitempty"`
+	Read         bool      `json:"read"`
+	Starred      bool      `json:"starred"`
+}
+
+func toRecord(a cache.Article) record {
+	return record{
+		ID:           a.ID,
+		Source:       a.Source,
+		Title:        a.Title,
+		Link:         a.Link,
+		Published:    a.Published,
+		Category:     a.Category,
+		Tags:         splitTags(a.Tags),
+		Description:  a.Description,
+		Summary:      a.Summary,
+		WhyItMatters: a.WhyItMatters,
+		FullSummary:  a.FullSummary,
+		Read:         a.Read,
+		Starred:      a.Starred,
+	}
+}
+
+func splitTags(tags string) []string {
+	var out []string
+	for _, t := range strings.Split(tags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func writeJSON(w io.Writer, articles []cache.Article) error {
+	records := make([]record, len(articles))
+	for i, a := range articles {
+		records[i] = toRecord(a)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+var csvHeader = []string{
+	"id", "source", "title", "link", "published", "category", "tags",
+	"description", "summary", "why_it_matters", "full_summary", "read", "starred",
+}
+
+func writeCSV(w io.Writer, articles []cache.Article) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, a := range articles {
+		r := toRecord(a)
+		err := cw.Write([]string{
+			r.ID, r.Source, r.Title, r.Link, r.Published.Format(time.RFC3339), r.Category,
+			strings.Join(r.Tags, ", "), r.Description, r.Summary, r.WhyItMatters, r.FullSummary,
+			strconv.FormatBool(r.Read), strconv.FormatBool(r.Starred),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeMarkdown renders a digest: one section per article with its AI
+// summary when available, falling back to the feed description.
+func writeMarkdown(w io.Writer, articles []cache.Article) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# devnews digest — %s\n\n", time.Now().Format("Jan 2, 2006"))
+	fmt.Fprintf(&b, "%d article(s)\n", len(articles))
+
+	for _, a := range articles {
+		fmt.Fprintf(&b, "\n## [%s](%s)\n\n", escapeMarkdown(a.Title), a.Link)
+
+		meta := []string{a.Source, a.Published.Format("Jan 2, 2006")}
+		if a.Category != "" {
+			meta = append(meta, a.Category)
+		}
+		fmt.Fprintf(&b, "*%s*\n", strings.Join(meta, " · "))
+
+		if a.WhyItMatters != "" {
+			fmt.Fprintf(&b, "\n**Why it matters:** %s\n", oneLine(a.WhyItMatters))
+		}
+
+		switch {
+		case a.FullSummary != "":
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(a.FullSummary))
+		case a.Summary != "":
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(a.Summary))
+		case a.Description != "":
+			fmt.Fprintf(&b, "\n%s\n", oneLine(a.Description))
+		}
+
+		if tags := splitTags(a.Tags); len(tags) > 0 {
+			fmt.Fprintf(&b, "\nTags: %s\n", strings.Join(tags, ", "))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var markdownEscaper = strings.NewReplacer(`[`, `\[`, `]`, `\]`)
+
+// escapeMarkdown keeps brackets in titles from breaking link syntax.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(oneLine(s))
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+func sampleArticles() []cache.Article {
+	published := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+	return []cache.Article{
+		{
+			ID: "aaa", Source: "Stripe", Title: "Idempotency [deep dive]", Link: "https://stripe.com/blog/idem",
+			Description: "How we\nretry safely", Published: published, Category: "Distributed Systems",
+			Tags: "payments, retries", Summary: "Short summary", WhyItMatters: "Retries are everywhere",
+			FullSummary: "A longer AI summary.", Starred: true,
+		},
+		{
+			ID: "bbb", Source: "GitHub", Title: "Plain post", Link: "https://github.blog/plain",
+			Description: "Only a description", Published: published.Add(-time.Hour),
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"markdown": Markdown, "MD": Markdown, "json": JSON, " csv ": CSV} {
+		got, err := ParseFormat(input)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JSON, sampleArticles()); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var got []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, buf.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(got))
+	}
+	first := got[0]
+	for key, want := range map[string]interface{}{
+		"summary":        "Short summary",
+		"why_it_matters": "Retries are everywhere",
+		"full_summary":   "A longer AI summary.",
+		"published":      "2026-10-12T09:30:00Z",
+		"starred":        true,
+	} {
+		if first[key] != want {
+			t.Errorf("%s = %v, want %v", key, first[key], want)
+		}
+	}
+	if tags, _ := first["tags"].([]interface{}); len(tags) != 2 || tags[1] != "retries" {
+		t.Errorf("expected split tags, got %v", first["tags"])
+	}
+	if _, ok := got[1]["full_summary"]; ok {
+		t.Error("expected empty AI fields to be omitted")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, sampleArticles()); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "id" || len(rows[0]) != len(rows[1]) {
+		t.Errorf("unexpected header %v", rows[0])
+	}
+	if rows[1][7] != "How we\nretry safely" {
+		t.Errorf("expected multi-line description preserved, got %q", rows[1][7])
+	}
+	if rows[1][10] != "A longer AI summary." {
+		t.Errorf("expected full summary column, got %q", rows[1][10])
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Markdown, sampleArticles()); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	md := buf.String()
+	for _, want := range []string{
+		"## [Idempotency \\[deep dive\\]](https://stripe.com/blog/idem)",
+		"*Stripe · Oct 12, 2026 · Distributed Systems*",
+		"**Why it matters:** Retries are everywhere",
+		"A longer AI summary.",
+		"Tags: payments, retries",
+		"## [Plain post](https://github.blog/plain)",
+		"Only a description",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in markdown:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Short summary") {
+		t.Error("expected full summary to take precedence over the short summary")
+	}
+}
@@ -0,0 +1,178 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mmcdole/gofeed"
+)
+
+// ErrNoFeeds is returned by Discover when a page advertises no feeds and
+// none of the common feed paths exist.
+var ErrNoFeeds = errors.New("no feeds found")
+
+// Candidate is a feed found by Discover.
+type Candidate struct {
+	URL   string
+	Title string
+	Type  string // source type: "rss" or "atom"
+}
+
+// commonFeedPaths are probed when a page has no <link rel="alternate"> tags.
+var commonFeedPaths = []string{
+	"/feed", "/feed.xml", "/rss", "/rss.xml", "/atom.xml", "/index.xml", "/blog/feed", "/blog/rss.xml",
+}
+
+// feedLinkTypes maps the MIME types of advertised feeds to source types.
+var feedLinkTypes = map[string]string{
+	"application/rss+xml":   "rss",
+	"application/atom+xml":  "atom",
+	"application/feed+json": "rss",
+}
+
+const maxDiscoverSize = 2 << 20 // 2MB max download
+
+var discoverClient = &http.Client{Timeout: 15 * time.Second}
+
+// Discover finds the feeds for a URL. A URL that already points at a feed is
+// returned as the only candidate. For an HTML page, the feeds advertised with
+// <link rel="alternate"> are returned in page order; when there are none, the
+// common feed paths on the same host are probed instead. A URL without a
+// scheme is assumed to be https.
+func Discover(ctx context.Context, rawURL string) ([]Candidate, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	body, base, err := download(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := parseCandidate(rawURL, body); ok {
+		return []Candidate{c}, nil
+	}
+
+	candidates, err := advertisedFeeds(base, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		candidates = probeCommonPaths(ctx, base)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w at %s", ErrNoFeeds, rawURL)
+	}
+	return candidates, nil
+}
+
+// download fetches a page and returns its body and final URL after redirects.
+func download(ctx context.Context, rawURL string) ([]byte, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching %s: %w", rawURL, err)
+	}
+	req.Header.Set("User-Agent", "devnews/1.0 (feed reader)")
+
+	resp, err := discoverClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("fetching %s: http error: %s", rawURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDiscoverSize))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading %s: %w", rawURL, err)
+	}
+	return body, resp.Request.URL, nil
+}
+
+// parseCandidate reports whether body is a feed, returning it as a candidate
+// for feedURL.
+func parseCandidate(feedURL string, body []byte) (Candidate, bool) {
+	f, err := gofeed.NewParser().Parse(bytes.NewReader(body))
+	if err != nil {
+		return Candidate{}, false
+	}
+	typ := "rss"
+	if f.FeedType == "atom" {
+		typ = "atom"
+	}
+	return Candidate{URL: feedURL, Title: strings.TrimSpace(f.Title), Type: typ}, true
+}
+
+// advertisedFeeds reads the <link rel="alternate"> feed tags from a page.
+func advertisedFeeds(base *url.URL, body []byte) ([]Candidate, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", base, err)
+	}
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if u, err := base.Parse(href); err == nil {
+			base = u
+		}
+	}
+
+	var candidates []Candidate
+	seen := make(map[string]bool)
+	doc.Find(`link[rel~="alternate"][href]`).Each(func(_ int, s *goquery.Selection) {
+		mime, _ := s.Attr("type")
+		typ, ok := feedLinkTypes[strings.ToLower(strings.TrimSpace(mime))]
+		if !ok {
+			return
+		}
+		href, _ := s.Attr("href")
+		u, err := base.Parse(strings.TrimSpace(href))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || seen[u.String()] {
+			return
+		}
+		seen[u.String()] = true
+		title, _ := s.Attr("title")
+		candidates = append(candidates, Candidate{URL: u.String(), Title: strings.TrimSpace(title), Type: typ})
+	})
+	return candidates, nil
+}
+
+// probeCommonPaths fetches the common feed paths concurrently and returns
+// the ones that parse as feeds, in commonFeedPaths order. Paths that redirect
+// to the same feed are reported once, under the final URL.
+func probeCommonPaths(ctx context.Context, base *url.URL) []Candidate {
+	found := make([]*Candidate, len(commonFeedPaths))
+	var wg sync.WaitGroup
+	for i, path := range commonFeedPaths {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			u := &url.URL{Scheme: base.Scheme, Host: base.Host, Path: path}
+			body, final, err := download(ctx, u.String())
+			if err != nil {
+				return
+			}
+			if c, ok := parseCandidate(final.String(), body); ok {
+				found[i] = &c
+			}
+		}(i, path)
+	}
+	wg.Wait()
+
+	var candidates []Candidate
+	seen := make(map[string]bool)
+	for _, c := range found {
+		if c != nil && !seen[c.URL] {
+			seen[c.URL] = true
+			candidates = append(candidates, *c)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const discoverRSS = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Example Blog</title>
+<item><title>Hello</title><link>https://example.com/hello</link></item>
+</channel></rss>`
+
+const discoverAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Example Atom</title></feed>`
+
+func discoverServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDiscoverFeedURL(t *testing.T) {
+	srv := discoverServer(t, map[string]string{"/feed.xml": discoverRSS})
+
+	got, err := Discover(context.Background(), srv.URL+"/feed.xml")
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(got) != 1 || got[0].URL != srv.URL+"/feed.xml" || got[0].Title != "Example Blog" || got[0].Type != "rss" {
+		t.Errorf("expected the feed itself as the only candidate, got %+v", got)
+	}
+}
+
+func TestDiscoverAdvertisedFeeds(t *testing.T) {
+	page := `<html><head>
+<link rel="alternate" type="application/rss+xml" title="Posts" href="/posts.rss">
+<link rel="alternate" type="application/atom+xml" href="https://other.example.com/atom.xml">
+<link rel="alternate" type="application/rss+xml" href="/posts.rss">
+<link rel="alternate" hreflang="de" href="/de/">
+<link rel="stylesheet" type="text/css" href="/style.css">
+</head><body></body></html>`
+	srv := discoverServer(t, map[string]string{"/": page})
+
+	got, err := Discover(context.Background(), srv.URL+"/")
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 candidates, got %+v", got)
+	}
+	if got[0].URL != srv.URL+"/posts.rss" || got[0].Title != "Posts" || got[0].Type != "rss" {
+		t.Errorf("expected relative link resolved against the page, got %+v", got[0])
+	}
+	if got[1].URL != "https://other.example.com/atom.xml" || got[1].Type != "atom" {
+		t.Errorf("unexpected second candidate: %+v", got[1])
+	}
+}
+
+func TestDiscoverProbesCommonPaths(t *testing.T) {
+	srv := discoverServer(t, map[string]string{
+		"/blog":     "<html><head><title>No feeds here</title></head></html>",
+		"/rss.xml":  discoverRSS,
+		"/atom.xml": discoverAtom,
+	})
+
+	got, err := Discover(context.Background(), srv.URL+"/blog")
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(got) != 2 || got[0].URL != srv.URL+"/rss.xml" || got[1].URL != srv.URL+"/atom.xml" {
+		t.Errorf("expected probed feeds in path order, got %+v", got)
+	}
+}
+
+func TestDiscoverNoFeeds(t *testing.T) {
+	srv := discoverServer(t, map[string]string{"/": "<html><body>hi</body></html>"})
+
+	if _, err := Discover(context.Background(), srv.URL+"/"); !errors.Is(err, ErrNoFeeds) {
+		t.Errorf("expected ErrNoFeeds, got %v", err)
+	}
+}
+
+func TestDiscoverAssumesHTTPS(t *testing.T) {
+	_, err := Discover(context.Background(), "127.0.0.1:1/feed")
+	if err == nil || !strings.Contains(err.Error(), "https://127.0.0.1:1/feed") {
+		t.Errorf("expected the URL to be fetched over https, got %v", err)
+	}
+}
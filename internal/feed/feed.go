@@ -3,7 +3,9 @@ package feed
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -13,26 +15,93 @@ import (
 	"github.com/mmcdole/gofeed"
 )
 
+// Fetcher retrieves articles for a single source. Implementations are
+// registered per source type with Register.
 type Fetcher interface {
 	Fetch(ctx context.Context, source config.Source) ([]cache.Article, error)
 }
 
+// ErrNotModified is returned by a ConditionalFetcher when the server reports
+// that the feed has not changed since the validators were issued.
+var ErrNotModified = errors.New("feed not modified")
+
+// ConditionalFetcher is implemented by fetchers that can skip unchanged feeds
+// using HTTP cache validators (ETag / Last-Modified).
+type ConditionalFetcher interface {
+	Fetcher
+	FetchConditional(ctx context.Context, source config.Source, prev cache.FeedValidators) ([]cache.Article, cache.FeedValidators, error)
+}
+
+// ValidatorStore persists HTTP cache validators between refreshes.
+// *cache.Cache satisfies it.
+type ValidatorStore interface {
+	FeedValidators(source string) (cache.FeedValidators, error)
+	SetFeedValidators(source string, v cache.FeedValidators) error
+}
+
 type RSSFetcher struct {
 	parser *gofeed.Parser
+	client *http.Client
 }
 
 func NewRSSFetcher() *RSSFetcher {
-	return &RSSFetcher{parser: gofeed.NewParser()}
+	return &RSSFetcher{
+		parser: gofeed.NewParser(),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
 }
 
 func (f *RSSFetcher) Fetch(ctx context.Context, source config.Source) ([]cache.Article, error) {
-	feed, err := f.parser.ParseURLWithContext(source.URL, ctx)
+	articles, _, err := f.FetchConditional(ctx, source, cache.FeedValidators{})
+	return articles, err
+}
+
+// FetchConditional fetches the feed, sending If-None-Match / If-Modified-Since
+// from prev. A 304 response returns ErrNotModified without parsing anything.
+// The returned validators are the ones to store for the next request.
+func (f *RSSFetcher) FetchConditional(ctx context.Context, source config.Source, prev cache.FeedValidators) ([]cache.Article, cache.FeedValidators, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("fetching %s: %w", source.Name, err)
+		return nil, prev, fmt.Errorf("fetching %s: %w", source.Name, err)
+	}
+	req.Header.Set("User-Agent", "devnews/1.0 (feed reader)")
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
 	}
 
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, prev, fmt.Errorf("fetching %s: %w", source.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, prev, ErrNotModified
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, prev, fmt.Errorf("fetching %s: http error: %s", source.Name, resp.Status)
+	}
+
+	feed, err := f.parser.Parse(resp.Body)
+	if err != nil {
+		return nil, prev, fmt.Errorf("fetching %s: %w", source.Name, err)
+	}
+
+	next := cache.FeedValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	return toArticles(source, feed), next, nil
+}
+
+// toArticles converts feed items, dropping those older than the source's
+// backfill window.
+func toArticles(source config.Source, feed *gofeed.Feed) []cache.Article {
 	now := time.Now()
-	maxAge := now.Add(-7 * 24 * time.Hour)
+	maxAge := now.Add(-source.BackfillWindow())
 	articles := make([]cache.Article, 0, len(feed.Items))
 	for _, item := range feed.Items {
 		pub := now
@@ -42,7 +111,6 @@ func (f *RSSFetcher) Fetch(ctx context.Context, source config.Source) ([]cache.A
 			pub = *item.UpdatedParsed
 		}
 
-		// Skip articles older than 7 days
 		if pub.Before(maxAge) {
 			continue
 		}
@@ -63,7 +131,7 @@ func (f *RSSFetcher) Fetch(ctx context.Context, source config.Source) ([]cache.A
 			FetchedAt:   now,
 		})
 	}
-	return articles, nil
+	return articles
 }
 
 func articleID(link string) string {
@@ -82,35 +150,142 @@ func truncate(s string, n int) string {
 	return string(runes[:n-3]) + "..."
 }
 
+// SourceResult describes the outcome of fetching a single source.
+type SourceResult struct {
+	Name        string
+	Items       int
+	Latency     time.Duration
+	NotModified bool
+	Err         error
+}
+
 type FetchResult struct {
-	Articles []cache.Article
-	Errors   []error
+	Articles    []cache.Article
+	Errors      []error
+	NotModified []string // sources whose feed was unchanged since the last refresh
+	Sources     []SourceResult
+
+	// Validators holds updated cache validators per source. They are not
+	// persisted by FetchAll; call SaveValidators once the articles are stored
+	// so a failed write never causes the next refresh to skip them.
+	Validators map[string]cache.FeedValidators
+}
+
+// HealthRecorder stores per-source fetch outcomes. *cache.Cache satisfies it.
+type HealthRecorder interface {
+	RecordFetch(a cache.FetchAttempt) error
 }
 
-func FetchAll(ctx context.Context, sources []config.Source) FetchResult {
+// RecordHealth stores the outcome of every source in the result.
+func (r FetchResult) RecordHealth(rec HealthRecorder) error {
+	for _, s := range r.Sources {
+		err := rec.RecordFetch(cache.FetchAttempt{
+			Source:      s.Name,
+			Items:       s.Items,
+			Latency:     s.Latency,
+			NotModified: s.NotModified,
+			Err:         s.Err,
+		})
+		if err != nil {
+			return fmt.Errorf("recording health for %s: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// SaveValidators persists the validators collected during FetchAll.
+func (r FetchResult) SaveValidators(store ValidatorStore) error {
+	for source, v := range r.Validators {
+		if err := store.SetFeedValidators(source, v); err != nil {
+			return fmt.Errorf("saving validators for %s: %w", source, err)
+		}
+	}
+	return nil
+}
+
+// Options controls a FetchAll run.
+type Options struct {
+	// Validators enables conditional requests for fetchers that support them.
+	// Nil fetches every feed in full.
+	Validators ValidatorStore
+}
+
+func FetchAll(ctx context.Context, sources []config.Source, opts Options) FetchResult {
 	var (
 		mu     sync.Mutex
 		result FetchResult
 		wg     sync.WaitGroup
 	)
 
-	fetcher := NewRSSFetcher()
-
 	for _, src := range sources {
+		fetcher, ok := Lookup(src.Type)
+		if !ok {
+			err := fmt.Errorf("fetching %s: no fetcher registered for type %q", src.Name, src.Type)
+			result.Errors = append(result.Errors, err)
+			result.Sources = append(result.Sources, SourceResult{Name: src.Name, Err: err})
+			continue
+		}
+
 		wg.Add(1)
 		go func(s config.Source) {
 			defer wg.Done()
-			articles, err := fetcher.Fetch(ctx, s)
+			start := time.Now()
+			articles, validators, err := fetch(ctx, fetcher, s, opts.Validators)
+			sr := SourceResult{Name: s.Name, Items: len(articles), Latency: time.Since(start)}
+
 			mu.Lock()
 			defer mu.Unlock()
+			if errors.Is(err, ErrNotModified) {
+				sr.NotModified = true
+				result.Sources = append(result.Sources, sr)
+				result.NotModified = append(result.NotModified, s.Name)
+				return
+			}
 			if err != nil {
+				sr.Err = err
+				result.Sources = append(result.Sources, sr)
 				result.Errors = append(result.Errors, err)
 				return
 			}
+			result.Sources = append(result.Sources, sr)
 			result.Articles = append(result.Articles, articles...)
+			if validators != nil {
+				if result.Validators == nil {
+					result.Validators = make(map[string]cache.FeedValidators)
+				}
+				result.Validators[s.Name] = *validators
+			}
 		}(src)
 	}
 
 	wg.Wait()
 	return result
 }
+
+// fetch runs a single source, using conditional requests when both the
+// fetcher and a validator store are available. It returns non-nil validators
+// only when they changed and should be saved. A source whose backfill window
+// grew since its validators were saved is fetched in full, since a 304 would
+// skip the older items now wanted.
+func fetch(ctx context.Context, fetcher Fetcher, source config.Source, store ValidatorStore) ([]cache.Article, *cache.FeedValidators, error) {
+	cf, ok := fetcher.(ConditionalFetcher)
+	if !ok || store == nil {
+		articles, err := fetcher.Fetch(ctx, source)
+		return articles, nil, err
+	}
+
+	window := source.BackfillWindow()
+	prev, err := store.FeedValidators(source.Name)
+	if err != nil || prev.Backfill < window {
+		prev = cache.FeedValidators{}
+	}
+	articles, next, err := cf.FetchConditional(ctx, source, prev)
+	if err != nil {
+		return nil, nil, err
+	}
+	next.Backfill = window
+	if next == prev {
+		return articles, nil, nil
+	}
+	return articles, &next, nil
+}
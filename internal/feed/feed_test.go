@@ -1,9 +1,20 @@
 package feed
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
 	"github.com/matheuskafuri/devnews/internal/scrape"
+	"github.com/mmcdole/gofeed"
 )
 
 func TestArticleID(t *testing.T) {
@@ -72,3 +83,169 @@ func TestStripHTML(t *testing.T) {
 		}
 	}
 }
+
+// conditionalFeedServer serves a one-item RSS feed with an ETag and honors
+// If-None-Match. It counts full (200) responses.
+func conditionalFeedServer(t *testing.T, fullResponses *int32) *httptest.Server {
+	t.Helper()
+	const etag = `"v1"`
+	pub := time.Now().Add(-time.Hour).Format(time.RFC1123Z)
+	body := fmt.Sprintf(`<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Test</title>
+<item><title>Hello</title><link>https://example.com/hello</link><description>First post</description><pubDate>%s</pubDate></item>
+</channel></rss>`, pub)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(fullResponses, 1)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchConditionalNotModified(t *testing.T) {
+	var full int32
+	srv := conditionalFeedServer(t, &full)
+	src := config.Source{Name: "Test", Type: "rss", URL: srv.URL, Enabled: true}
+	f := NewRSSFetcher()
+
+	articles, v, err := f.FetchConditional(context.Background(), src, cache.FeedValidators{})
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d", len(articles))
+	}
+	if v.ETag != `"v1"` || v.LastModified == "" {
+		t.Errorf("expected validators from response, got %+v", v)
+	}
+
+	articles, _, err = f.FetchConditional(context.Background(), src, v)
+	if !errors.Is(err, ErrNotModified) {
+		t.Fatalf("expected ErrNotModified, got %v", err)
+	}
+	if len(articles) != 0 {
+		t.Errorf("expected no articles on 304, got %d", len(articles))
+	}
+	if full != 1 {
+		t.Errorf("expected 1 full response, got %d", full)
+	}
+}
+
+func TestFetchAllUsesStoredValidators(t *testing.T) {
+	var full int32
+	srv := conditionalFeedServer(t, &full)
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("opening cache: %v", err)
+	}
+	defer db.Close()
+
+	sources := []config.Source{{Name: "Test", Type: "rss", URL: srv.URL, Enabled: true}}
+
+	first := FetchAll(context.Background(), sources, Options{Validators: db})
+	if len(first.Errors) != 0 || len(first.Articles) != 1 {
+		t.Fatalf("first refresh: articles=%d errors=%v", len(first.Articles), first.Errors)
+	}
+	if err := first.SaveValidators(db); err != nil {
+		t.Fatalf("SaveValidators: %v", err)
+	}
+
+	second := FetchAll(context.Background(), sources, Options{Validators: db})
+	if len(second.Errors) != 0 {
+		t.Fatalf("second refresh errors: %v", second.Errors)
+	}
+	if len(second.NotModified) != 1 || second.NotModified[0] != "Test" {
+		t.Errorf("expected Test reported as not modified, got %v", second.NotModified)
+	}
+	if full != 1 {
+		t.Errorf("expected feed body served once, got %d", full)
+	}
+}
+
+func TestFetchAllRefetchesWhenBackfillGrows(t *testing.T) {
+	var full int32
+	srv := conditionalFeedServer(t, &full)
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("opening cache: %v", err)
+	}
+	defer db.Close()
+
+	source := config.Source{Name: "Test", Type: "rss", URL: srv.URL, Enabled: true}
+	first := FetchAll(context.Background(), []config.Source{source}, Options{Validators: db})
+	if err := first.SaveValidators(db); err != nil {
+		t.Fatalf("SaveValidators: %v", err)
+	}
+
+	// A wider window sends no validators, and the new window is saved.
+	source.Backfill = "90d"
+	second := FetchAll(context.Background(), []config.Source{source}, Options{Validators: db})
+	if len(second.NotModified) != 0 || full != 2 {
+		t.Fatalf("expected a full fetch after the window grew, got %d full responses, not modified %v", full, second.NotModified)
+	}
+	if err := second.SaveValidators(db); err != nil {
+		t.Fatalf("SaveValidators: %v", err)
+	}
+	if v, _ := db.FeedValidators("Test"); v.Backfill != source.BackfillWindow() {
+		t.Errorf("expected the 90d window saved, got %v", v.Backfill)
+	}
+
+	// The same or a narrower window uses the validators again.
+	source.Backfill = "30d"
+	third := FetchAll(context.Background(), []config.Source{source}, Options{Validators: db})
+	if len(third.NotModified) != 1 || full != 2 {
+		t.Errorf("expected not modified with a narrower window, got %d full responses", full)
+	}
+}
+
+func TestFetchAllReportsPerSourceResults(t *testing.T) {
+	var full int32
+	ok := conditionalFeedServer(t, &full)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	}))
+	defer broken.Close()
+
+	result := FetchAll(context.Background(), []config.Source{
+		{Name: "Good", Type: "rss", URL: ok.URL, Enabled: true},
+		{Name: "Dead", Type: "rss", URL: broken.URL, Enabled: true},
+	}, Options{})
+
+	if len(result.Sources) != 2 {
+		t.Fatalf("expected 2 source results, got %d", len(result.Sources))
+	}
+	bySource := map[string]SourceResult{}
+	for _, s := range result.Sources {
+		bySource[s.Name] = s
+	}
+	if good := bySource["Good"]; good.Err != nil || good.Items != 1 || good.Latency <= 0 {
+		t.Errorf("unexpected result for Good: %+v", good)
+	}
+	if dead := bySource["Dead"]; dead.Err == nil {
+		t.Errorf("expected error for Dead, got %+v", dead)
+	}
+}
+
+func TestToArticlesHonorsBackfillWindow(t *testing.T) {
+	recent := time.Now().Add(-24 * time.Hour)
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	f := &gofeed.Feed{Items: []*gofeed.Item{
+		{Title: "Recent", Link: "https://example.com/recent", PublishedParsed: &recent},
+		{Title: "Old", Link: "https://example.com/old", PublishedParsed: &old},
+	}}
+
+	if got := toArticles(config.Source{Name: "Test"}, f); len(got) != 1 || got[0].Title != "Recent" {
+		t.Errorf("expected only the recent item with the default window, got %d items", len(got))
+	}
+	if got := toArticles(config.Source{Name: "Test", Backfill: "60d"}, f); len(got) != 2 {
+		t.Errorf("expected both items with a 60d window, got %d items", len(got))
+	}
+}
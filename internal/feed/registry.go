@@ -1,5 +1,56 @@
 package feed
 
-// Registry is reserved for future source type extensibility.
-// Currently FetchAll uses RSSFetcher directly, which handles both RSS and Atom
-// via the gofeed library.
+import (
+	"sort"
+	"sync"
+
+	"github.com/matheuskafuri/devnews/internal/config"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Fetcher)
+)
+
+func init() {
+	rss := NewRSSFetcher()
+	Register("rss", rss)
+	Register("atom", rss)
+}
+
+// Register makes a Fetcher available for sources of the given type and marks
+// the type as valid for config validation. Registering a type twice replaces
+// the previous fetcher, which lets callers override the built-in handlers.
+func Register(sourceType string, f Fetcher) {
+	if sourceType == "" {
+		panic("feed: Register with empty source type")
+	}
+	if f == nil {
+		panic("feed: Register fetcher is nil for type " + sourceType)
+	}
+	registryMu.Lock()
+	registry[sourceType] = f
+	registryMu.Unlock()
+
+	config.RegisterSourceType(sourceType)
+}
+
+// Lookup returns the Fetcher registered for a source type.
+func Lookup(sourceType string) (Fetcher, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	f, ok := registry[sourceType]
+	return f, ok
+}
+
+// Types returns the registered source types in sorted order.
+func Types() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	types := make([]string, 0, len(registry))
+	for t := range registry {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
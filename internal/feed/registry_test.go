@@ -0,0 +1,80 @@
+package feed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+)
+
+type stubFetcher struct {
+	articles []cache.Article
+}
+
+func (s stubFetcher) Fetch(ctx context.Context, source config.Source) ([]cache.Article, error) {
+	out := make([]cache.Article, len(s.articles))
+	for i, a := range s.articles {
+		a.Source = source.Name
+		out[i] = a
+	}
+	return out, nil
+}
+
+func TestBuiltinTypesRegistered(t *testing.T) {
+	for _, typ := range []string{"rss", "atom"} {
+		if _, ok := Lookup(typ); !ok {
+			t.Errorf("expected built-in type %q to be registered", typ)
+		}
+	}
+}
+
+func TestRegisterCustomType(t *testing.T) {
+	Register("stub-wiki", stubFetcher{articles: []cache.Article{{ID: "w1", Title: "Wiki page"}}})
+
+	found := false
+	for _, typ := range Types() {
+		if typ == "stub-wiki" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected stub-wiki in Types(), got %v", Types())
+	}
+
+	result := FetchAll(context.Background(), []config.Source{
+		{Name: "Internal Wiki", Type: "stub-wiki", URL: "https://wiki.example.com", Enabled: true},
+	}, Options{})
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(result.Articles) != 1 || result.Articles[0].Source != "Internal Wiki" {
+		t.Errorf("expected 1 article from Internal Wiki, got %+v", result.Articles)
+	}
+}
+
+func TestRegisterMakesTypeValidInConfig(t *testing.T) {
+	Register("stub-json", stubFetcher{})
+
+	found := false
+	for _, typ := range config.SourceTypes() {
+		if typ == "stub-json" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected stub-json in config.SourceTypes(), got %v", config.SourceTypes())
+	}
+}
+
+func TestFetchAllUnknownType(t *testing.T) {
+	result := FetchAll(context.Background(), []config.Source{
+		{Name: "Mystery", Type: "carrier-pigeon", URL: "https://example.com", Enabled: true},
+	}, Options{})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 error for unregistered type, got %v", result.Errors)
+	}
+	if len(result.Articles) != 0 {
+		t.Errorf("expected no articles, got %d", len(result.Articles))
+	}
+}
@@ -0,0 +1,93 @@
+// Package opml reads and writes OPML subscription lists, the format feed
+// readers use to exchange their sources.
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Feed is a single subscription.
+type Feed struct {
+	Title string
+	URL   string // feed URL (xmlUrl)
+	Type  string // e.g. "rss"; empty when the document does not say
+}
+
+type document struct {
+	XMLName xml.Name  `xml:"opml"`
+	Version string    `xml:"version,attr"`
+	Head    head      `xml:"head"`
+	Body    []outline `xml:"body>outline"`
+}
+
+type head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+type outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	Type     string    `xml:"type,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	Outlines []outline `xml:"outline"`
+}
+
+// Write encodes feeds as an OPML 2.0 document.
+func Write(w io.Writer, title string, feeds []Feed) error {
+	doc := document{
+		Version: "2.0",
+		Head:    head{Title: title, DateCreated: time.Now().UTC().Format(time.RFC1123Z)},
+	}
+	for _, f := range feeds {
+		typ := f.Type
+		if typ == "" {
+			typ = "rss"
+		}
+		doc.Body = append(doc.Body, outline{Text: f.Title, Title: f.Title, Type: typ, XMLURL: f.URL})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding opml: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Parse reads the feeds from an OPML document. Outlines nested in folders are
+// flattened, and outlines without an xmlUrl (the folders themselves) are
+// skipped. The title falls back to the outline text when absent.
+func Parse(r io.Reader) ([]Feed, error) {
+	var doc document
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parsing opml: %w", err)
+	}
+
+	var feeds []Feed
+	var walk func([]outline)
+	walk = func(outlines []outline) {
+		for _, o := range outlines {
+			if url := strings.TrimSpace(o.XMLURL); url != "" {
+				title := strings.TrimSpace(o.Title)
+				if title == "" {
+					title = strings.TrimSpace(o.Text)
+				}
+				feeds = append(feeds, Feed{Title: title, URL: url, Type: strings.ToLower(strings.TrimSpace(o.Type))})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body)
+	return feeds, nil
+}
@@ -0,0 +1,94 @@
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	feeds := []Feed{
+		{Title: "Stripe", URL: "https://stripe.com/blog/feed.rss", Type: "rss"},
+		{Title: "R&D <Blog>", URL: "https://example.com/atom.xml?a=1&b=2"},
+	}
+	if err := Write(&buf, "devnews sources", feeds); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, xml.Header) {
+		t.Errorf("expected xml header, got %q", out[:40])
+	}
+
+	var doc document
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid xml: %v\n%s", err, out)
+	}
+	if doc.Version != "2.0" || doc.Head.Title != "devnews sources" {
+		t.Errorf("unexpected head: %+v", doc)
+	}
+	if len(doc.Body) != 2 {
+		t.Fatalf("expected 2 outlines, got %d", len(doc.Body))
+	}
+	if doc.Body[1].Text != "R&D <Blog>" || doc.Body[1].XMLURL != "https://example.com/atom.xml?a=1&b=2" {
+		t.Errorf("expected escaped values to round-trip, got %+v", doc.Body[1])
+	}
+	if doc.Body[1].Type != "rss" {
+		t.Errorf("expected default type rss, got %q", doc.Body[1].Type)
+	}
+}
+
+func TestParse(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="1.0">
+  <head><title>My feeds</title></head>
+  <body>
+    <outline text="Engineering">
+      <outline text="Stripe" title="Stripe Engineering" type="rss" xmlUrl="https://stripe.com/blog/feed.rss" htmlUrl="https://stripe.com/blog"/>
+      <outline text="Julia Evans" type="ATOM" xmlUrl=" https://jvns.ca/atom.xml "/>
+    </outline>
+    <outline text="Lobsters" xmlUrl="https://lobste.rs/rss"/>
+    <outline text="Just a folder"/>
+  </body>
+</opml>`
+
+	feeds, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []Feed{
+		{Title: "Stripe Engineering", URL: "https://stripe.com/blog/feed.rss", Type: "rss"},
+		{Title: "Julia Evans", URL: "https://jvns.ca/atom.xml", Type: "atom"},
+		{Title: "Lobsters", URL: "https://lobste.rs/rss"},
+	}
+	if len(feeds) != len(want) {
+		t.Fatalf("expected %d feeds, got %+v", len(want), feeds)
+	}
+	for i := range want {
+		if feeds[i] != want[i] {
+			t.Errorf("feed %d = %+v, want %+v", i, feeds[i], want[i])
+		}
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	in := []Feed{{Title: "A & B", URL: "https://a.com/feed?x=1&y=2", Type: "atom"}}
+	if err := Write(&buf, "test", in); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(out) != 1 || out[0] != in[0] {
+		t.Errorf("round trip: got %+v, want %+v", out, in)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse(strings.NewReader("not xml at all")); err == nil {
+		t.Error("expected error for invalid document")
+	}
+}
@@ -0,0 +1,183 @@
+// Package query parses the search syntax accepted by the TUI's / prompt.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/classify"
+)
+
+const dateLayout = "2006-01-02"
+
+var filterKeys = map[string]bool{
+	"source": true, "cat": true, "category": true, "tag": true,
+	"is": true, "after": true, "before": true,
+}
+
+// Parse turns a search string into query options. Plain words become
+// full-text search terms and the following filters are recognised:
+//
+//	source:stripe       source name contains "stripe" (repeat to match any of several)
+//	cat:security        category, by alias or full name
+//	tag:rust            AI-generated tag
+//	is:unread           only articles not yet opened
+//	is:starred          only starred articles
+//	after:2026-09-01    published on or after the date
+//	before:2026-10-01   published before the date
+//	"exact phrase"      words must appear together
+//	-word, -"a phrase"  exclude matching articles
+//
+// Filter values may be quoted to include spaces (source:"pragmatic engineer").
+// Unknown prefixes such as "http:" are treated as plain text. Only the filter
+// fields of the returned options are set; callers merge in their own Since,
+// Sources and ordering.
+func Parse(input string) (cache.QueryOpts, error) {
+	var (
+		opts  cache.QueryOpts
+		words []string
+	)
+
+	for _, tok := range tokenize(input) {
+		key := strings.ToLower(tok.key)
+		if key != "" && !filterKeys[key] {
+			// Not a filter: keep the text, colon and all, as a search term.
+			key = ""
+			tok.value = strings.TrimPrefix(tok.raw, "-")
+		}
+
+		if tok.negate {
+			if key != "" {
+				return cache.QueryOpts{}, fmt.Errorf("cannot negate filter %s: (only words and phrases can be excluded)", tok.key)
+			}
+			if tok.value != "" {
+				opts.Exclude = append(opts.Exclude, tok.value)
+			}
+			continue
+		}
+		if tok.quoted && key == "" {
+			opts.Phrases = append(opts.Phrases, tok.value)
+			continue
+		}
+
+		switch key {
+		case "":
+			words = append(words, tok.value)
+		case "source":
+			if tok.value != "" {
+				opts.SourceNames = append(opts.SourceNames, tok.value)
+			}
+		case "cat", "category":
+			cat, err := classify.ResolveAlias(tok.value)
+			if err != nil {
+				return cache.QueryOpts{}, fmt.Errorf("invalid filter cat:%s: %w", tok.value, err)
+			}
+			opts.Category = string(cat)
+		case "tag":
+			if tok.value != "" {
+				opts.Tags = append(opts.Tags, tok.value)
+			}
+		case "is":
+			switch strings.ToLower(tok.value) {
+			case "unread":
+				opts.Unread = true
+			case "starred":
+				opts.Starred = true
+			default:
+				return cache.QueryOpts{}, fmt.Errorf("unknown filter is:%s (valid: is:unread, is:starred)", tok.value)
+			}
+		case "after":
+			t, err := parseDate("after", tok.value)
+			if err != nil {
+				return cache.QueryOpts{}, err
+			}
+			opts.Since = t
+		case "before":
+			t, err := parseDate("before", tok.value)
+			if err != nil {
+				return cache.QueryOpts{}, err
+			}
+			opts.Until = t
+		}
+	}
+
+	opts.Search = strings.Join(words, " ")
+	return opts, nil
+}
+
+func parseDate(key, value string) (time.Time, error) {
+	t, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %s:%s (expected YYYY-MM-DD)", key, value)
+	}
+	return t, nil
+}
+
+type token struct {
+	raw    string // original text, used when a key turns out not to be a filter
+	key    string // filter name before the colon, "" for plain terms
+	value  string
+	quoted bool
+	negate bool
+}
+
+// tokenize splits input on whitespace, keeping quoted sections together.
+// An unterminated quote runs to the end of the input.
+func tokenize(input string) []token {
+	var tokens []token
+	runes := []rune(input)
+	i := 0
+	for i < len(runes) {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			break
+		}
+		start := i
+
+		var tok token
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			tok.negate = true
+			i++
+		}
+
+		// Optional key: letters followed by a colon.
+		j := i
+		for j < len(runes) && unicode.IsLetter(runes[j]) {
+			j++
+		}
+		if j > i && j < len(runes) && runes[j] == ':' {
+			tok.key = string(runes[i:j])
+			i = j + 1
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			tok.quoted = true
+			i++
+			end := i
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			tok.value = string(runes[i:end])
+			i = end
+			if i < len(runes) {
+				i++ // closing quote
+			}
+		} else {
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) {
+				end++
+			}
+			tok.value = string(runes[i:end])
+			i = end
+		}
+
+		tok.raw = string(runes[start:i])
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
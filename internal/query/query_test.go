@@ -0,0 +1,117 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/classify"
+)
+
+func TestParsePlainWords(t *testing.T) {
+	opts, err := Parse("  rate   limiting ")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if opts.Search != "rate limiting" {
+		t.Errorf("expected search %q, got %q", "rate limiting", opts.Search)
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	opts, err := Parse(`source:stripe source:"pragmatic engineer" cat:security tag:rust is:unread after:2026-09-01 before:2026-10-01 outage`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if want := []string{"stripe", "pragmatic engineer"}; !reflect.DeepEqual(opts.SourceNames, want) {
+		t.Errorf("expected sources %v, got %v", want, opts.SourceNames)
+	}
+	if opts.Category != string(classify.Security) {
+		t.Errorf("expected category Security, got %q", opts.Category)
+	}
+	if want := []string{"rust"}; !reflect.DeepEqual(opts.Tags, want) {
+		t.Errorf("expected tags %v, got %v", want, opts.Tags)
+	}
+	if !opts.Unread {
+		t.Error("expected unread filter")
+	}
+	if want := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local); !opts.Since.Equal(want) {
+		t.Errorf("expected since %v, got %v", want, opts.Since)
+	}
+	if want := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local); !opts.Until.Equal(want) {
+		t.Errorf("expected until %v, got %v", want, opts.Until)
+	}
+	if opts.Search != "outage" {
+		t.Errorf("expected search %q, got %q", "outage", opts.Search)
+	}
+}
+
+func TestParsePhrasesAndNegation(t *testing.T) {
+	opts, err := Parse(`"zero downtime" deploys -kubernetes -"service mesh"`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := []string{"zero downtime"}; !reflect.DeepEqual(opts.Phrases, want) {
+		t.Errorf("expected phrases %v, got %v", want, opts.Phrases)
+	}
+	if want := []string{"kubernetes", "service mesh"}; !reflect.DeepEqual(opts.Exclude, want) {
+		t.Errorf("expected exclude %v, got %v", want, opts.Exclude)
+	}
+	if opts.Search != "deploys" {
+		t.Errorf("expected search %q, got %q", "deploys", opts.Search)
+	}
+}
+
+func TestParseUnterminatedQuote(t *testing.T) {
+	opts, err := Parse(`"event sourcing`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := []string{"event sourcing"}; !reflect.DeepEqual(opts.Phrases, want) {
+		t.Errorf("expected phrases %v, got %v", want, opts.Phrases)
+	}
+}
+
+func TestParseUnknownKeyIsText(t *testing.T) {
+	opts, err := Parse("http:2 -foo:bar")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if opts.Search != "http:2" {
+		t.Errorf("expected unknown key kept as text, got %q", opts.Search)
+	}
+	if want := []string{"foo:bar"}; !reflect.DeepEqual(opts.Exclude, want) {
+		t.Errorf("expected exclude %v, got %v", want, opts.Exclude)
+	}
+}
+
+func TestParseCaseInsensitiveKeys(t *testing.T) {
+	opts, err := Parse("Source:GitHub IS:UNREAD is:Starred Cat:DB")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(opts.SourceNames) != 1 || opts.SourceNames[0] != "GitHub" {
+		t.Errorf("expected source GitHub, got %v", opts.SourceNames)
+	}
+	if !opts.Unread || !opts.Starred {
+		t.Error("expected unread and starred filters")
+	}
+	if opts.Category != string(classify.Databases) {
+		t.Errorf("expected Databases, got %q", opts.Category)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		"cat:gardening",
+		"is:everything",
+		"after:yesterday",
+		"before:2026-13-01",
+		"-source:stripe",
+	} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected error", input)
+		}
+	}
+}
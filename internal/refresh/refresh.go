@@ -0,0 +1,122 @@
+// Package refresh fetches the enabled sources into the cache. It is shared by
+// the TUI, the refresh on launch and the refresh command.
+package refresh
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/matheuskafuri/devnews/internal/feed"
+)
+
+// DefaultTimeout bounds a whole refresh when Options.Timeout is unset.
+const DefaultTimeout = 30 * time.Second
+
+// Options controls a refresh.
+type Options struct {
+	// Timeout bounds fetching all sources. Zero uses DefaultTimeout.
+	Timeout time.Duration
+
+	// Backfill, when set, overrides every source's backfill window and
+	// downloads each feed in full, ignoring cached validators. Old articles
+	// are not pruned afterwards, since that would undo the import.
+	Backfill time.Duration
+}
+
+// Summary reports what a refresh did.
+type Summary struct {
+	Sources     int           // enabled sources fetched
+	Articles    int           // articles fetched and stored
+	NotModified int           // sources whose feed was unchanged
+	Pruned      int64         // articles removed by retention
+	Errors      []error       // per-source fetch failures
+	Duration    time.Duration // wall time of the whole refresh
+}
+
+// Failed reports whether any source failed to fetch.
+func (s Summary) Failed() bool {
+	return len(s.Errors) > 0
+}
+
+func (s Summary) String() string {
+	msg := fmt.Sprintf("Fetched %d article(s) from %d source(s)", s.Articles, s.Sources)
+	if s.NotModified > 0 {
+		msg += fmt.Sprintf(", %d unchanged", s.NotModified)
+	}
+	if s.Pruned > 0 {
+		msg += fmt.Sprintf(", pruned %d", s.Pruned)
+	}
+	if s.Failed() {
+		msg += fmt.Sprintf(", %d failed", len(s.Errors))
+	}
+	return msg + fmt.Sprintf(" in %s.", s.Duration.Round(10*time.Millisecond))
+}
+
+// Run fetches every enabled source, records per-source health, stores the
+// articles and prunes those past the retention period. Source failures are
+// reported in the Summary; the error is only set when the cache could not be
+// written, in which case nothing was marked as refreshed.
+func Run(ctx context.Context, cfg *config.Config, db *cache.Cache, opts Options) (Summary, error) {
+	start := time.Now()
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	sources := cfg.EnabledSources()
+	fetchOpts := feed.Options{Validators: db}
+	if opts.Backfill > 0 {
+		for i := range sources {
+			sources[i].Backfill = opts.Backfill.String()
+		}
+		// A 304 would skip the older items we are asking for.
+		fetchOpts.Validators = nil
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	result := feed.FetchAll(fetchCtx, sources, fetchOpts)
+	cancel()
+
+	summary := Summary{
+		Sources:     len(sources),
+		Articles:    len(result.Articles),
+		NotModified: len(result.NotModified),
+		Errors:      result.Errors,
+	}
+	if err := result.RecordHealth(db); err != nil {
+		return summary, err
+	}
+	if err := db.UpsertArticles(result.Articles); err != nil {
+		return summary, fmt.Errorf("caching articles: %w", err)
+	}
+	if err := result.SaveValidators(db); err != nil {
+		return summary, err
+	}
+	if err := db.SetLastRefresh(); err != nil {
+		return summary, err
+	}
+
+	if opts.Backfill == 0 {
+		pruned, err := db.Prune(cfg.RetentionDuration())
+		if err != nil {
+			return summary, err
+		}
+		summary.Pruned = pruned
+	}
+	summary.Duration = time.Since(start)
+	return summary, nil
+}
+
+// Jitter returns d shifted by a random amount of up to a tenth either way, so
+// that many clients on the same schedule do not hit the feeds in lockstep.
+func Jitter(d time.Duration) time.Duration {
+	spread := int64(d / 10)
+	if spread <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int64N(2*spread+1)-spread)
+}
@@ -0,0 +1,117 @@
+package refresh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+)
+
+func feedServer(t *testing.T, ages ...time.Duration) *httptest.Server {
+	t.Helper()
+	items := ""
+	for i, age := range ages {
+		items += fmt.Sprintf("<item><title>Post %d</title><link>https://example.com/%d</link><pubDate>%s</pubDate></item>",
+			i, i, time.Now().Add(-age).Format(time.RFC1123Z))
+	}
+	body := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` + items + `</channel></rss>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			http.Error(w, "gone", http.StatusGone)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func openCache(t *testing.T) *cache.Cache {
+	t.Helper()
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestRunStoresAndPrunes(t *testing.T) {
+	srv := feedServer(t, time.Hour, 2*time.Hour)
+	db := openCache(t)
+	old := cache.Article{ID: "old", Source: "Test", Title: "Ancient", Link: "https://example.com/old", Published: time.Now().Add(-60 * 24 * time.Hour)}
+	if err := db.UpsertArticles([]cache.Article{old}); err != nil {
+		t.Fatalf("UpsertArticles: %v", err)
+	}
+
+	cfg := &config.Config{Retention: "30d", Sources: []config.Source{
+		{Name: "Test", Type: "rss", URL: srv.URL + "/feed", Enabled: true},
+		{Name: "Broken", Type: "rss", URL: srv.URL + "/broken", Enabled: true},
+		{Name: "Off", Type: "rss", URL: srv.URL + "/off", Enabled: false},
+	}}
+
+	summary, err := Run(context.Background(), cfg, db, Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if summary.Sources != 2 || summary.Articles != 2 || summary.Pruned != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if !summary.Failed() || len(summary.Errors) != 1 {
+		t.Errorf("expected the broken source to be reported, got %v", summary.Errors)
+	}
+	if db.NeedsRefresh(time.Hour) {
+		t.Error("expected the refresh time to be recorded")
+	}
+
+	articles, err := db.GetArticles(cache.QueryOpts{})
+	if err != nil {
+		t.Fatalf("GetArticles: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Errorf("expected 2 cached articles after pruning, got %d", len(articles))
+	}
+}
+
+func TestRunBackfill(t *testing.T) {
+	srv := feedServer(t, time.Hour, 20*24*time.Hour, 200*24*time.Hour)
+	db := openCache(t)
+	cfg := &config.Config{Retention: "7d", Sources: []config.Source{
+		{Name: "Test", Type: "rss", URL: srv.URL + "/feed", Enabled: true},
+	}}
+
+	summary, err := Run(context.Background(), cfg, db, Options{Backfill: 90 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if summary.Articles != 2 || summary.Pruned != 0 {
+		t.Errorf("expected 2 articles within 90d and no pruning, got %+v", summary)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	d := time.Hour
+	for i := 0; i < 100; i++ {
+		got := Jitter(d)
+		if got < 54*time.Minute || got > 66*time.Minute {
+			t.Fatalf("Jitter(%v) = %v, want within 10%%", d, got)
+		}
+	}
+	if got := Jitter(5); got != 5 {
+		t.Errorf("expected tiny durations unchanged, got %v", got)
+	}
+}
+
+func TestSummaryString(t *testing.T) {
+	s := Summary{Sources: 3, Articles: 12, NotModified: 1, Pruned: 4, Errors: []error{fmt.Errorf("x")}, Duration: 1500 * time.Millisecond}
+	want := "Fetched 12 article(s) from 3 source(s), 1 unchanged, pruned 4, 1 failed in 1.5s."
+	if got := s.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
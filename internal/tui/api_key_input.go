@@ -37,10 +37,10 @@ func (a *App) handleAPIKeyInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (a *App) handleAPIKeySaved(apiKey string) (tea.Model, tea.Cmd) {
 	if a.cfg.AI == nil {
-		a.cfg.AI = &config.AIConfig{Provider: "openai", Model: "gpt-4o-mini"}
+		a.cfg.AI = &config.AIConfig{Provider: "openai", Model: config.DefaultModels["openai"]}
 	}
 	a.cfg.AI.APIKey = apiKey
-	s, err := ai.New(a.cfg.AI, apiKey)
+	s, err := ai.New(a.cfg.AI, apiKey, a.db)
 	if err != nil {
 		a.err = err
 		a.mode = modeNormal
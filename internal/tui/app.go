@@ -15,7 +15,8 @@ import (
 	"github.com/matheuskafuri/devnews/internal/browser"
 	"github.com/matheuskafuri/devnews/internal/cache"
 	"github.com/matheuskafuri/devnews/internal/config"
-	"github.com/matheuskafuri/devnews/internal/feed"
+	"github.com/matheuskafuri/devnews/internal/query"
+	"github.com/matheuskafuri/devnews/internal/refresh"
 	"github.com/matheuskafuri/devnews/internal/scrape"
 	"github.com/matheuskafuri/devnews/internal/update"
 )
@@ -45,9 +46,10 @@ const (
 	modeHelp
 	modeBriefingOpening
 	modeBriefingCard
-	modeRequestSource
+	modeSources
 	modeAPIKeyInput
 	modeThemePicker
+	modeQueue
 )
 
 type App struct {
@@ -65,17 +67,29 @@ type App struct {
 
 	// Sub-components
 	searchInput textinput.Model
+	search      cache.QueryOpts // last query that parsed; reloads use it
 	spinner     spinner.Model
 	filterBar   filterBar
 
 	// AI
 	summarizer ai.Summarizer
-
-	// Source request form
-	sourceNameInput textinput.Model
-	sourceURLInput  textinput.Model
-	sourceFormFocus int
-	statusMessage   string
+	aiErr      error // reported once at launch
+
+	// Source manager
+	configPath          string
+	sourceCursor        int
+	builtinSources      map[string]bool
+	sourceForm          bool   // the add/edit form is open
+	sourceEditing       string // name of the source being edited; empty when adding
+	sourceType          string
+	sourceNameInput     textinput.Model
+	sourceURLInput      textinput.Model
+	sourceFormFocus     int
+	sourceChecking      bool // a trial fetch is running
+	sourcePreview       *sourcePreview
+	sourceCandidate     int // cursor in the discovered feeds
+	sourceConfirmDelete bool
+	statusMessage       string
 
 	// API key input
 	apiKeyInput    textinput.Model
@@ -87,8 +101,18 @@ type App struct {
 
 	summaryLoading map[string]bool // article IDs currently being summarized
 
+	// Prefetch progress; both are zero when no prefetch is running.
+	prefetchDone  int
+	prefetchTotal int
+	refreshed     bool // feeds were refreshed just before launch
+
 	// State
 	refreshing         bool
+	failingSources     []string
+	searchSortByDate   bool // order search results by date instead of relevance
+	savedOnly          bool // browse shows starred articles only
+	queue              []cache.QueueItem
+	queueCursor        int
 	since              time.Time
 	previewScroll      int
 	currentDate        string
@@ -107,16 +131,18 @@ type RunOpts struct {
 	Since          time.Time
 	Streak         int
 	Summarizer     ai.Summarizer
+	AIErr          error // why Summarizer could not be created; shown in the status bar
 	BrowseMode     bool
 	BriefingV2     *briefing.Briefing
 	CurrentVersion string
+	ConfigPath     string // config file edited by the source manager; empty for the default
+	Refreshed      bool   // feeds were refreshed just before launch, so prefetch runs
 }
 
 func NewApp(opts RunOpts) *App {
-	// Apply theme from config
-	if opts.Cfg.Theme != "" {
-		applyTheme(GetTheme(opts.Cfg.Theme))
-	}
+	// Apply theme and category colors from config
+	setCategoryColors(opts.Cfg.Categories)
+	applyTheme(GetTheme(opts.Cfg.Theme))
 
 	ti := textinput.New()
 	ti.Placeholder = "Search articles..."
@@ -147,10 +173,12 @@ func NewApp(opts RunOpts) *App {
 
 	return &App{
 		cfg:            opts.Cfg,
+		configPath:     opts.ConfigPath,
 		db:             opts.DB,
 		since:          opts.Since,
 		streak:         opts.Streak,
 		summarizer:     opts.Summarizer,
+		aiErr:          opts.AIErr,
 		filterBar:      newFilterBar(opts.Cfg.SourceNames()),
 		searchInput:    ti,
 		sourceNameInput: nameInput,
@@ -162,6 +190,7 @@ func NewApp(opts RunOpts) *App {
 		briefingV2:     opts.BriefingV2,
 		currentVersion: opts.CurrentVersion,
 		summaryLoading: make(map[string]bool),
+		refreshed:      opts.Refreshed,
 	}
 }
 
@@ -173,11 +202,23 @@ func (a *App) Init() tea.Cmd {
 		cmds = append(cmds, a.loadArticlesCmd())
 	}
 
+	cmds = append(cmds, a.loadSourceHealthCmd(), a.loadQueueCmd(nil))
+	if a.aiErr != nil {
+		cmds = append(cmds, a.setStatus("Error: AI: "+a.aiErr.Error()))
+	}
+
 	// Async AI enrichment for V2 briefing
 	if a.summarizer != nil && a.briefingV2 != nil {
 		cmds = append(cmds, a.fetchWhyItMatters()...)
 		cmds = append(cmds, a.fetchThemes())
 	}
+	if a.summarizer != nil {
+		cmds = append(cmds, a.resumeAIJobsCmd())
+	}
+	if a.refreshed {
+		cmds = append(cmds, a.prefetchCmd())
+	}
+	cmds = append(cmds, a.classifyCmd())
 
 	// Async update check
 	if a.currentVersion != "" && a.currentVersion != "dev" && a.db.ShouldCheckUpdate() {
@@ -198,28 +239,110 @@ func (a *App) Init() tea.Cmd {
 
 func (a *App) fetchWhyItMatters() []tea.Cmd {
 	var cmds []tea.Cmd
-	s := a.summarizer
-	db := a.db
-	for i, card := range a.briefingV2.Cards {
-		if card.Article.WhyItMatters != "" && card.Article.WhyItMatters != briefing.DescriptionExcerpt(card.Article.Description) {
+	for _, card := range a.briefingV2.Cards {
+		if !briefing.NeedsWhyItMatters(card) {
 			continue // already has AI-generated text
 		}
-		idx := i
-		art := card.Article
-		cmds = append(cmds, func() tea.Msg {
-			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-			defer cancel()
-			text, err := s.WhyItMatters(ctx, art.Title, art.Description)
-			if err != nil || text == "" {
-				return nil
-			}
-			db.UpdateArticleWhyItMatters(art.ID, text)
-			return whyItMattersMsg{cardIndex: idx, articleID: art.ID, text: text}
-		})
+		cmds = append(cmds, a.aiJobCmd(cache.AIJob{Article: card.Article, Kind: cache.AIJobWhyItMatters}))
 	}
 	return cmds
 }
 
+// aiJobTimeout bounds a queued AI job, including time spent waiting for the
+// summarizer's rate limit and retries.
+const aiJobTimeout = time.Minute
+
+// resumeAIJobsLimit caps the jobs left over from earlier runs that are
+// retried at startup.
+const resumeAIJobsLimit = 20
+
+// aiJobCmd queues an AI job in the cache and runs it, so it is retried on a
+// later launch if it fails or devnews exits first.
+func (a *App) aiJobCmd(job cache.AIJob) tea.Cmd {
+	s := a.summarizer
+	db := a.db
+	return func() tea.Msg {
+		db.EnqueueAIJob(job.Article.ID, job.Kind)
+		return runAIJob(s, db, job)
+	}
+}
+
+// runAIJob runs job, returning the message that applies its result, or nil
+// when it failed. Only a job already queued in the cache is retried later.
+func runAIJob(s ai.Summarizer, db *cache.Cache, job cache.AIJob) tea.Msg {
+	ctx, cancel := context.WithTimeout(context.Background(), aiJobTimeout)
+	defer cancel()
+	res, err := ai.RunJob(ctx, s, db, job)
+	if err != nil {
+		return nil // non-fatal; the job stays queued
+	}
+	switch job.Kind {
+	case cache.AIJobSummarize:
+		return summaryLoadedMsg{articleID: job.Article.ID, result: res.Summary}
+	case cache.AIJobWhyItMatters:
+		if res.WhyItMatters != "" {
+			return whyItMattersMsg{articleID: job.Article.ID, text: res.WhyItMatters}
+		}
+	}
+	return nil
+}
+
+// prefetchCmd plans the AI summaries to fetch after a refresh when
+// ai.prefetch is on.
+func (a *App) prefetchCmd() tea.Cmd {
+	p := a.cfg.GetPrefetch()
+	if a.summarizer == nil || !p.Enabled {
+		return nil
+	}
+	db := a.db
+	sources := a.cfg.SourceNames()
+	return func() tea.Msg {
+		jobs, err := ai.PrefetchJobs(db, sources, p.Count, p.Budget)
+		if err != nil || len(jobs) == 0 {
+			return nil
+		}
+		return prefetchPlannedMsg{jobs: jobs}
+	}
+}
+
+// classifyCmd sends articles the AI has not categorized yet to it in the
+// background when ai.classify is on.
+func (a *App) classifyCmd() tea.Cmd {
+	c, ok := a.summarizer.(ai.Classifier)
+	if !ok || a.cfg.AI == nil || !a.cfg.AI.Classify {
+		return nil
+	}
+	db := a.db
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), briefing.ClassifyTimeout)
+		defer cancel()
+		briefing.Classify(ctx, db, c) // non-fatal; unplaced articles keep keyword labels
+		return articlesClassifiedMsg{}
+	}
+}
+
+// prefetchJobCmd runs one prefetch job and reports that it finished.
+func (a *App) prefetchJobCmd(job cache.AIJob) tea.Cmd {
+	s := a.summarizer
+	db := a.db
+	return func() tea.Msg {
+		db.EnqueueAIJob(job.Article.ID, job.Kind)
+		return prefetchJobDoneMsg{result: runAIJob(s, db, job)}
+	}
+}
+
+// resumeAIJobsCmd loads the AI jobs left unfinished by earlier runs.
+func (a *App) resumeAIJobsCmd() tea.Cmd {
+	db := a.db
+	return func() tea.Msg {
+		jobs, err := db.PendingAIJobs(resumeAIJobsLimit)
+		if err != nil || len(jobs) == 0 {
+			return nil
+		}
+		return aiJobsLoadedMsg{jobs: jobs}
+	}
+}
+
 func (a *App) fetchThemes() tea.Cmd {
 	s := a.summarizer
 	cards := a.briefingV2.Cards
@@ -239,12 +362,22 @@ func (a *App) fetchThemes() tea.Cmd {
 }
 
 // loadArticlesCmd captures current query state into the closure to avoid races.
+// It uses the last search that parsed, so a bad query never breaks reloads.
 func (a *App) loadArticlesCmd() tea.Cmd {
-	opts := cache.QueryOpts{
-		Since:   a.since,
-		Sources: a.filterBar.activeSources(),
-		Search:  a.searchInput.Value(),
+	opts := a.search
+	if a.savedOnly {
+		// Saved articles are listed regardless of age or source filter.
+		opts.Starred = true
+	} else {
+		if opts.Since.IsZero() {
+			opts.Since = a.since
+		}
+		// A source: filter in the query takes precedence over the filter bar.
+		if len(opts.SourceNames) == 0 {
+			opts.Sources = a.filterBar.activeSources()
+		}
 	}
+	opts.OrderByDate = a.searchSortByDate
 	db := a.db
 	return func() tea.Msg {
 		articles, err := db.GetArticles(opts)
@@ -255,21 +388,37 @@ func (a *App) loadArticlesCmd() tea.Cmd {
 	}
 }
 
+// loadSourceHealthCmd reads which enabled sources keep failing to fetch.
+func (a *App) loadSourceHealthCmd() tea.Cmd {
+	db := a.db
+	enabled := make(map[string]bool)
+	for _, name := range a.cfg.SourceNames() {
+		enabled[name] = true
+	}
+	return func() tea.Msg {
+		records, err := db.SourceHealth()
+		if err != nil {
+			return feedErrMsg{err: fmt.Errorf("source health: %w", err)}
+		}
+		var failing []string
+		for _, h := range records {
+			if enabled[h.Source] && h.Failing() {
+				failing = append(failing, h.Source)
+			}
+		}
+		return sourceHealthMsg{failing: failing}
+	}
+}
+
 func (a *App) doRefresh() tea.Cmd {
 	cfg := a.cfg
 	db := a.db
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		result := feed.FetchAll(ctx, cfg.EnabledSources())
-
-		if err := db.UpsertArticles(result.Articles); err != nil {
-			return refreshDoneMsg{errs: append(result.Errors, err)}
+		summary, err := refresh.Run(context.Background(), cfg, db, refresh.Options{})
+		if err != nil {
+			return refreshDoneMsg{errs: summary.Errors, err: err}
 		}
-		db.SetLastRefresh()
-
-		return refreshDoneMsg{count: len(result.Articles), errs: result.Errors}
+		return refreshDoneMsg{count: summary.Articles, errs: summary.Errors}
 	}
 }
 
@@ -308,10 +457,28 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case refreshDoneMsg:
 		a.refreshing = false
+		if msg.err != nil {
+			a.err = fmt.Errorf("refresh: %w", msg.err)
+		}
+		return a, tea.Batch(a.loadArticlesCmd(), a.loadSourceHealthCmd(), a.prefetchCmd(), a.classifyCmd())
+
+	case articlesClassifiedMsg:
 		return a, a.loadArticlesCmd()
 
+	case queueLoadedMsg:
+		a.queue = msg.items
+		if a.queueCursor >= len(a.queue) {
+			a.queueCursor = max(0, len(a.queue)-1)
+		}
+		return a, nil
+
+	case sourceHealthMsg:
+		a.failingSources = msg.failing
+		a.filterBar.setFailing(msg.failing)
+		return a, nil
+
 	case summaryLoadedMsg:
-		// Update the article in our local slice
+		// Update the article in our local slice (the job saved it to the cache)
 		tags := strings.Join(msg.result.Tags, ", ")
 		for i := range a.articles {
 			if a.articles[i].ID == msg.articleID {
@@ -320,14 +487,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 		}
-		// Persist to cache asynchronously
-		db := a.db
-		id := msg.articleID
-		summary := msg.result.Summary
-		return a, func() tea.Msg {
-			db.UpdateArticleSummary(id, summary, tags)
-			return nil
-		}
+		return a, nil
 
 	case fullSummaryLoadedMsg:
 		delete(a.summaryLoading, msg.articleID)
@@ -354,11 +514,40 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a.handleAPIKeySaved(msg.apiKey)
 
 	case whyItMattersMsg:
-		if a.briefingV2 != nil && msg.cardIndex < len(a.briefingV2.Cards) {
-			a.briefingV2.Cards[msg.cardIndex].Article.WhyItMatters = msg.text
+		if a.briefingV2 != nil {
+			for i := range a.briefingV2.Cards {
+				if a.briefingV2.Cards[i].Article.ID == msg.articleID {
+					a.briefingV2.Cards[i].Article.WhyItMatters = msg.text
+				}
+			}
 		}
 		return a, nil
 
+	case prefetchPlannedMsg:
+		a.prefetchTotal += len(msg.jobs)
+		cmds := make([]tea.Cmd, len(msg.jobs))
+		for i, job := range msg.jobs {
+			cmds[i] = a.prefetchJobCmd(job)
+		}
+		return a, tea.Batch(cmds...)
+
+	case prefetchJobDoneMsg:
+		a.prefetchDone++
+		if a.prefetchDone >= a.prefetchTotal {
+			a.prefetchDone, a.prefetchTotal = 0, 0
+		}
+		if msg.result != nil {
+			return a.Update(msg.result)
+		}
+		return a, nil
+
+	case aiJobsLoadedMsg:
+		cmds := make([]tea.Cmd, len(msg.jobs))
+		for i, job := range msg.jobs {
+			cmds[i] = a.aiJobCmd(job)
+		}
+		return a, tea.Batch(cmds...)
+
 	case themesMsg:
 		if a.briefingV2 != nil {
 			a.briefingV2.Themes = msg.themes
@@ -375,17 +564,40 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			a.statusMessage = "Source request submitted!"
 		}
-		a.mode = modeHome
 		return a, tea.Tick(3*time.Second, func(time.Time) tea.Msg {
 			return clearStatusMsg{}
 		})
 
+	case sourceCheckedMsg:
+		a.sourceChecking = false
+		if a.mode == modeSources && a.sourceForm {
+			a.setFormSource(msg.source)
+			a.sourceCandidate = 0
+			a.sourcePreview = &sourcePreview{source: msg.source, articles: msg.articles, candidates: msg.candidates, err: msg.err}
+		}
+		return a, nil
+
+	case sourcesSavedMsg:
+		if !msg.saved {
+			return a, a.setStatus("Error: " + msg.err.Error())
+		}
+		// The config file changed even if the cache step failed, so the
+		// overlay shows the sources as written.
+		a.cfg.Sources = msg.sources
+		a.filterBar.setSources(a.cfg.SourceNames())
+		a.openSourceManager()
+		status := msg.status
+		if msg.err != nil {
+			status = "Error: " + msg.err.Error()
+		}
+		return a, tea.Batch(a.setStatus(status), a.loadArticlesCmd())
+
 	case clearStatusMsg:
 		a.statusMessage = ""
 		return a, nil
 
 	case spinner.TickMsg:
-		if a.refreshing {
+		if a.refreshing || a.sourceChecking {
 			var cmd tea.Cmd
 			a.spinner, cmd = a.spinner.Update(msg)
 			return a, cmd
@@ -411,12 +623,14 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a.handleBriefingOpeningKey(msg)
 	case modeBriefingCard:
 		return a.handleBriefingCardKey(msg)
-	case modeRequestSource:
-		return a.handleRequestSourceKey(msg)
+	case modeSources:
+		return a.handleSourcesKey(msg)
 	case modeAPIKeyInput:
 		return a.handleAPIKeyInputKey(msg)
 	case modeThemePicker:
 		return a.handleThemePickerKey(msg)
+	case modeQueue:
+		return a.handleQueueKey(msg)
 	case modeSearch:
 		return a.handleSearchKey(msg)
 	case modeFilter:
@@ -507,6 +721,16 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		a.summaryLoading[a.articles[a.cursor].ID] = true
 		return a, a.fetchFullSummary()
+	case "s":
+		if len(a.articles) > 0 && a.cursor < len(a.articles) {
+			return a, a.toggleStar(a.articles[a.cursor].ID)
+		}
+		return a, nil
+	case "a":
+		if len(a.articles) > 0 && a.cursor < len(a.articles) {
+			return a, a.enqueueCmd(a.articles[a.cursor])
+		}
+		return a, nil
 	case "K":
 		return a, a.openAPIKeyInput(false)
 	case "T":
@@ -535,6 +759,48 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// toggleStar flips the starred flag of an article everywhere it is shown and
+// persists the change.
+func (a *App) toggleStar(id string) tea.Cmd {
+	starred := !a.isStarred(id)
+	for i := range a.articles {
+		if a.articles[i].ID == id {
+			a.articles[i].Starred = starred
+		}
+	}
+	if a.briefingV2 != nil {
+		for i := range a.briefingV2.Cards {
+			if a.briefingV2.Cards[i].Article.ID == id {
+				a.briefingV2.Cards[i].Article.Starred = starred
+			}
+		}
+	}
+
+	db := a.db
+	return func() tea.Msg {
+		if err := db.SetStarred(id, starred); err != nil {
+			return feedErrMsg{err: fmt.Errorf("saving star: %w", err)}
+		}
+		return nil
+	}
+}
+
+func (a *App) isStarred(id string) bool {
+	for _, art := range a.articles {
+		if art.ID == id {
+			return art.Starred
+		}
+	}
+	if a.briefingV2 != nil {
+		for _, card := range a.briefingV2.Cards {
+			if card.Article.ID == id {
+				return card.Article.Starred
+			}
+		}
+	}
+	return false
+}
+
 func (a *App) handleHomeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "b", "1":
@@ -543,19 +809,28 @@ func (a *App) handleHomeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 		a.mode = modeNormal
+		a.savedOnly = false
 		return a, a.loadArticlesCmd()
 	case "e", "2":
+		if a.savedOnly {
+			a.savedOnly = false
+			a.savedCursor = 0
+		}
 		a.mode = modeNormal
 		a.cursor = a.savedCursor
 		return a, a.loadArticlesCmd()
+	case "*", "3":
+		a.mode = modeNormal
+		a.savedOnly = true
+		a.cursor = 0
+		return a, a.loadArticlesCmd()
+	case "l", "4":
+		a.mode = modeQueue
+		a.queueCursor = 0
+		return a, a.loadQueueCmd(nil)
 	case "s":
-		a.mode = modeRequestSource
-		a.sourceNameInput.SetValue("")
-		a.sourceURLInput.SetValue("")
-		a.sourceFormFocus = 0
-		a.sourceNameInput.Focus()
-		a.sourceURLInput.Blur()
-		return a, textinput.Blink
+		a.openSourceManager()
+		return a, nil
 	case "q":
 		return a, tea.Quit
 	}
@@ -575,6 +850,7 @@ func (a *App) handleBriefingOpeningKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, tea.Quit
 	case "e":
 		a.mode = modeNormal
+		a.savedOnly = false
 		return a, a.loadArticlesCmd()
 	case "h":
 		a.mode = modeHome
@@ -603,8 +879,14 @@ func (a *App) handleBriefingCardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, openBrowserCmd(a.briefingV2.Cards[a.cardCursor].Article.Link)
 		}
 		return a, nil
+	case "s":
+		if a.briefingV2 != nil && a.cardCursor < len(a.briefingV2.Cards) {
+			return a, a.toggleStar(a.briefingV2.Cards[a.cardCursor].Article.ID)
+		}
+		return a, nil
 	case "e":
 		a.mode = modeNormal
+		a.savedOnly = false
 		return a, a.loadArticlesCmd()
 	case "h":
 		a.mode = modeHome
@@ -621,11 +903,23 @@ func (a *App) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.mode = modeNormal
 		a.searchInput.SetValue("")
 		a.searchInput.Blur()
+		a.search = cache.QueryOpts{}
 		return a, a.loadArticlesCmd()
 	case "enter":
+		// Keep the prompt open on a bad query so it can be fixed; the
+		// list still shows the last good results.
+		opts, err := query.Parse(a.searchInput.Value())
+		if err != nil {
+			a.err = fmt.Errorf("search: %w", err)
+			return a, nil
+		}
+		a.search = opts
 		a.mode = modeNormal
 		a.searchInput.Blur()
 		return a, a.loadArticlesCmd()
+	case "tab":
+		a.searchSortByDate = !a.searchSortByDate
+		return a, a.loadArticlesCmd()
 	}
 
 	var cmd tea.Cmd
@@ -684,7 +978,11 @@ func (a *App) breadcrumb() string {
 	case modeHome:
 		return searchPromptStyle.Render("Home")
 	case modeNormal, modeSearch, modeFilter, modeAPIKeyInput, modeThemePicker:
-		bc := searchPromptStyle.Render("Home") + sep + searchPromptStyle.Render("Browse")
+		view := "Browse"
+		if a.savedOnly {
+			view = "Saved"
+		}
+		bc := searchPromptStyle.Render("Home") + sep + searchPromptStyle.Render(view)
 		if a.mode == modeSearch {
 			bc += sep + helpDimStyle.Render("Search")
 		} else if a.mode == modeFilter {
@@ -696,10 +994,12 @@ func (a *App) breadcrumb() string {
 	case modeBriefingCard:
 		card := fmt.Sprintf("Card %d/%d", a.cardCursor+1, len(a.briefingV2.Cards))
 		return searchPromptStyle.Render("Home") + sep + searchPromptStyle.Render("Briefing") + sep + helpDimStyle.Render(card)
-	case modeRequestSource:
-		return searchPromptStyle.Render("Home") + sep + helpDimStyle.Render("Request Source")
+	case modeSources:
+		return searchPromptStyle.Render("Home") + sep + helpDimStyle.Render("Sources")
 	case modeHelp:
 		return searchPromptStyle.Render("Home") + sep + helpDimStyle.Render("Help")
+	case modeQueue:
+		return searchPromptStyle.Render("Home") + sep + helpDimStyle.Render("Read Later")
 	}
 	return ""
 }
@@ -709,15 +1009,19 @@ func (a *App) View() string {
 		return lipgloss.NewStyle().Foreground(colorAccent).Render("  devnews")
 	}
 
-	if a.mode == modeHome || a.mode == modeRequestSource {
+	if a.mode == modeHome || a.mode == modeSources {
 		hasBriefing := a.briefingV2 != nil && len(a.briefingV2.Cards) > 0
-		bg := renderHomeScreen(a.width, a.height, hasBriefing, a.updateVersion, a.statusMessage)
-		if a.mode == modeRequestSource {
-			overlay := a.renderRequestSourceOverlay()
-			bg = overlayCenter(bg, overlay, a.width, a.height)
-			return a.withBottomBar(bg, "tab switch  enter submit  esc cancel")
+		if a.mode == modeSources {
+			// The overlay shows its own status line.
+			bg := renderHomeScreen(a.width, a.height, hasBriefing, a.updateVersion, "", len(a.failingSources), a.queuedCount())
+			bg = overlayCenter(bg, a.renderSourcesOverlay(), a.width, a.height)
+			if a.sourceForm {
+				return a.withBottomBar(bg, "tab switch  enter check/save  esc back")
+			}
+			return a.withBottomBar(bg, "a add  e edit  space toggle  x delete  esc close")
 		}
-		return a.withBottomBar(bg, "b briefing  e browse  s request source  q quit")
+		bg := renderHomeScreen(a.width, a.height, hasBriefing, a.updateVersion, a.statusMessage, len(a.failingSources), a.queuedCount())
+		return a.withBottomBar(bg, "b briefing  e browse  * saved  l read later  s sources  q quit")
 	}
 
 	if a.mode == modeBriefingOpening && a.briefingV2 != nil {
@@ -727,7 +1031,14 @@ func (a *App) View() string {
 	if a.mode == modeBriefingCard && a.briefingV2 != nil && a.cardCursor < len(a.briefingV2.Cards) {
 		return a.withBottomBar(
 			renderCardView(a.briefingV2.Cards[a.cardCursor], len(a.briefingV2.Cards), a.width, a.height),
-			"n next  p prev  o open  e browse  h home  q quit",
+			"n next  p prev  o open  s star  e browse  h home  q quit",
+		)
+	}
+
+	if a.mode == modeQueue {
+		return a.withBottomBar(
+			renderQueueView(a.queue, a.queueCursor, a.width, a.height-1),
+			"enter open  J/K reorder  x remove  h home  q quit",
 		)
 	}
 
@@ -824,12 +1135,19 @@ func (a *App) View() string {
 		a.mode == modeSearch,
 		a.refreshing,
 		a.layout,
+		len(a.failingSources),
+		a.searchSortByDate,
+		a.prefetchProgress(),
 	)
 
 	if a.refreshing {
 		status = a.spinner.View() + " " + status
 	}
 
+	if a.statusMessage != "" {
+		status = statusBarStyle.Width(a.width).Render(a.statusMessage)
+	}
+
 	// Error display
 	if a.err != nil {
 		status = lipgloss.NewStyle().Foreground(colorAccent).Render(a.err.Error())
@@ -856,7 +1174,23 @@ func (a *App) View() string {
 	return view
 }
 
+// prefetchProgress describes a running prefetch for the status bar, e.g.
+// "AI 3/12", or returns "" when none is running.
+func (a *App) prefetchProgress() string {
+	if a.prefetchTotal == 0 {
+		return ""
+	}
+	return fmt.Sprintf("AI %d/%d", a.prefetchDone, a.prefetchTotal)
+}
+
+// openAPIKeyInput asks for an OpenAI key. A self-hosted provider needs no
+// key, and saving one would switch the config to OpenAI, so it shows an
+// error instead.
 func (a *App) openAPIKeyInput(pendingSummary bool) tea.Cmd {
+	if a.cfg.AI != nil && !a.cfg.AI.NeedsKey() {
+		a.err = fmt.Errorf("AI provider %s does not use an API key; check ai settings in the config", a.cfg.AI.Provider)
+		return nil
+	}
 	a.mode = modeAPIKeyInput
 	a.apiKeyInput.SetValue("")
 	a.apiKeyInput.Focus()
@@ -904,15 +1238,12 @@ func (a *App) maybeFetchSummary() tea.Cmd {
 	if article.Summary != "" {
 		return nil // already cached
 	}
+	// Not queued: the cursor passes over many articles, and summarizing
+	// them all on the next launch would spend tokens nobody asked for.
 	s := a.summarizer
+	db := a.db
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
-		result, err := s.Summarize(ctx, article.Title, article.Description)
-		if err != nil {
-			return nil // non-fatal
-		}
-		return summaryLoadedMsg{articleID: article.ID, result: result}
+		return runAIJob(s, db, cache.AIJob{Article: article, Kind: cache.AIJobSummarize})
 	}
 }
 
@@ -927,11 +1258,14 @@ func (a *App) renderHelp() string {
 		dim.Render("Actions") + "\n" +
 		"  o, enter      Open article in browser\n" +
 		"  v             Cycle layout (split/list/preview)\n" +
+		"  s             Star/unstar article (listed under Saved)\n" +
+		"  a             Add article to Read Later queue\n" +
 		"  S             AI summary of full article\n" +
 		"  K             Set/update OpenAI API key\n" +
 		"  T             Select theme\n" +
 		"  r             Refresh feeds\n" +
-		"  /             Search articles\n" +
+		"  /             Search articles (tab toggles relevance/date sort)\n" +
+		"                e.g. source:stripe cat:security is:unread -kubernetes\n" +
 		"  f             Toggle source filter mode\n\n" +
 		dim.Render("Filter Mode") + "\n" +
 		"  ↑↓←→, hjkl   Navigate source grid\n" +
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/matheuskafuri/devnews/internal/ai"
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+)
+
+func TestPrefetchProgress(t *testing.T) {
+	app := NewApp(RunOpts{Cfg: &config.Config{}})
+	app.articles = []cache.Article{{ID: "a"}, {ID: "b"}}
+
+	app.Update(prefetchPlannedMsg{jobs: []cache.AIJob{
+		{Article: app.articles[0], Kind: cache.AIJobSummarize},
+		{Article: app.articles[1], Kind: cache.AIJobSummarize},
+	}})
+	if got := app.prefetchProgress(); got != "AI 0/2" {
+		t.Errorf("expected AI 0/2, got %q", got)
+	}
+
+	app.Update(prefetchJobDoneMsg{result: summaryLoadedMsg{articleID: "b", result: ai.Result{Summary: "About b"}}})
+	if got := app.prefetchProgress(); got != "AI 1/2" {
+		t.Errorf("expected AI 1/2, got %q", got)
+	}
+	if app.articles[1].Summary != "About b" {
+		t.Errorf("expected the prefetched summary applied, got %q", app.articles[1].Summary)
+	}
+
+	app.Update(prefetchJobDoneMsg{}) // failed job
+	if got := app.prefetchProgress(); got != "" {
+		t.Errorf("expected no progress once every job finished, got %q", got)
+	}
+}
+
+func TestAPIKeyInputSkippedForSelfHostedProvider(t *testing.T) {
+	app := NewApp(RunOpts{Cfg: &config.Config{AI: &config.AIConfig{Provider: "ollama", Model: "llama3.2"}}})
+	app.openAPIKeyInput(false)
+	if app.mode == modeAPIKeyInput {
+		t.Error("expected no API key prompt for a self-hosted provider")
+	}
+	if app.err == nil {
+		t.Error("expected an error explaining that no key is used")
+	}
+
+	app = NewApp(RunOpts{Cfg: &config.Config{}})
+	app.openAPIKeyInput(false)
+	if app.mode != modeAPIKeyInput {
+		t.Error("expected the API key prompt without an AI provider")
+	}
+}
+
+// summarizeOnly answers Summarize and nothing else.
+type summarizeOnly struct {
+	ai.Summarizer
+}
+
+func (summarizeOnly) Summarize(context.Context, string, string) (ai.Result, error) {
+	return ai.Result{Summary: "A summary."}, nil
+}
+
+func TestCursorSummaryIsNotQueued(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	article := cache.Article{ID: "a", Source: "A", Title: "A", Link: "https://a.com/1"}
+	db.UpsertArticles([]cache.Article{article})
+
+	app := NewApp(RunOpts{Cfg: &config.Config{}, DB: db, Summarizer: summarizeOnly{}})
+	app.articles = []cache.Article{article}
+	msg := app.maybeFetchSummary()()
+	if m, ok := msg.(summaryLoadedMsg); !ok || m.result.Summary != "A summary." {
+		t.Fatalf("expected the summary, got %#v", msg)
+	}
+	if jobs, _ := db.PendingAIJobs(0); len(jobs) != 0 {
+		t.Errorf("expected no job queued for a cursor move, got %+v", jobs)
+	}
+	if stored, _ := db.FindArticle("a"); stored.Summary != "A summary." {
+		t.Errorf("expected the summary saved, got %q", stored.Summary)
+	}
+}
+
+func TestBadSearchKeepsReloadsWorking(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	app := NewApp(RunOpts{Cfg: &config.Config{}, DB: db})
+	app.mode = modeSearch
+	app.searchInput.SetValue("is:bogus")
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.err == nil {
+		t.Error("expected the bad query reported in the status bar")
+	}
+	if app.mode != modeSearch {
+		t.Error("expected the search prompt to stay open for a bad query")
+	}
+
+	if _, ok := app.loadArticlesCmd()().(feedsLoadedMsg); !ok {
+		t.Error("expected reloads to keep working after a bad query")
+	}
+}
+
+func TestRefreshErrorShown(t *testing.T) {
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	app := NewApp(RunOpts{Cfg: &config.Config{}, DB: db})
+	app.Update(refreshDoneMsg{err: errors.New("saving validators for A: disk full")})
+	if app.err == nil {
+		t.Error("expected a failed refresh write reported in the status bar")
+	}
+}
@@ -17,7 +17,7 @@ func renderOpeningScreen(b *briefing.Briefing, height int) string {
 	lines = append(lines, "  "+briefingV2MetaStyle.Render(fmt.Sprintf("Posts scanned: %d", b.Scanned)))
 
 	if b.Focus != "" {
-		lines = append(lines, "  "+briefingV2MetaStyle.Render(fmt.Sprintf("%s articles: %d", b.Focus, b.Selected)))
+		lines = append(lines, "  "+briefingV2MetaStyle.Render("Focus: "+b.Focus))
 	}
 
 	lines = append(lines, "  "+briefingV2MetaStyle.Render(fmt.Sprintf("Selected for briefing: %d", b.Selected)))
@@ -53,15 +53,18 @@ func renderCardView(card briefing.Card, total int, width, height int) string {
 
 	// Source · date line
 	pubDate := card.Article.Published.Format("Jan 2")
-	body = append(body, briefingV2MetaStyle.Render(card.Article.Source+" · "+pubDate))
+	sourceLine := briefingV2MetaStyle.Render(card.Article.Source + " · " + pubDate)
+	if card.Article.Starred {
+		sourceLine += "  " + starredStyle.Render("★ Saved")
+	}
+	body = append(body, sourceLine)
 
 	// Title
 	body = append(body, briefingV2TitleStyle.Render(card.Article.Title))
 	body = append(body, "")
 
 	// Category · enter hint
-	catStyle := categoryStyle(card.Article.Category)
-	meta := catStyle.Render(card.Article.Category) +
+	meta := categoryBadges(card.Article) +
 		briefingV2MetaStyle.Render("  ·  Press Enter to read more")
 	body = append(body, meta)
 
@@ -75,6 +78,15 @@ func renderCardView(card briefing.Card, total int, width, height int) string {
 		}
 	}
 
+	// Why it was picked
+	if len(card.Reasons) > 0 {
+		body = append(body, "")
+		wrapped := wrapText("Picked: "+briefing.ReasonText(card.Reasons), cardWidth-2)
+		for _, line := range strings.Split(wrapped, "\n") {
+			body = append(body, briefingV2MetaStyle.Render(line))
+		}
+	}
+
 	cardContent := strings.Join(body, "\n")
 
 	// Wrap in rounded border
@@ -12,6 +12,7 @@ const gridColumns = 3
 type filterBar struct {
 	sources    []string
 	active     map[string]bool
+	failing    map[string]bool // sources that keep failing to fetch
 	filterMode bool
 	gridCursor int // 0 = "All", 1..len(sources) = individual sources
 }
@@ -23,6 +24,24 @@ func newFilterBar(sources []string) filterBar {
 	}
 }
 
+// setSources replaces the source list after the config changes, dropping
+// selections for sources that are gone.
+func (f *filterBar) setSources(sources []string) {
+	f.sources = sources
+	keep := make(map[string]bool, len(sources))
+	for _, s := range sources {
+		keep[s] = true
+	}
+	for s := range f.active {
+		if !keep[s] {
+			delete(f.active, s)
+		}
+	}
+	if f.gridCursor > len(sources) {
+		f.gridCursor = len(sources)
+	}
+}
+
 func (f *filterBar) toggle(source string) {
 	if f.active[source] {
 		delete(f.active, source)
@@ -42,6 +61,13 @@ func (f *filterBar) toggleGrid() {
 	}
 }
 
+func (f *filterBar) setFailing(names []string) {
+	f.failing = make(map[string]bool, len(names))
+	for _, n := range names {
+		f.failing[n] = true
+	}
+}
+
 func (f *filterBar) selectAll() {
 	f.active = make(map[string]bool)
 }
@@ -211,10 +237,18 @@ func (f *filterBar) renderOverlay() string {
 		isActive[i+1] = f.active[s]
 	}
 
+	// Failing sources get a trailing warning marker
+	markers := make([]string, total)
+	for i, s := range f.sources {
+		if f.failing[s] {
+			markers[i+1] = " !"
+		}
+	}
+
 	// Find max item name width for uniform columns
 	maxNameWidth := 0
-	for _, name := range items {
-		w := lipgloss.Width(name)
+	for i, name := range items {
+		w := lipgloss.Width(name + markers[i])
 		if w > maxNameWidth {
 			maxNameWidth = w
 		}
@@ -256,8 +290,12 @@ func (f *filterBar) renderOverlay() string {
 				nameStyled = overlayInactiveNameStyle.Render(name)
 			}
 
+			if markers[idx] != "" {
+				nameStyled += failingSourceStyle.Render(markers[idx])
+			}
+
 			// Pad name to fixed visual width
-			pad := maxNameWidth - lipgloss.Width(name)
+			pad := maxNameWidth - lipgloss.Width(name+markers[idx])
 			if pad < 0 {
 				pad = 0
 			}
@@ -273,7 +311,11 @@ func (f *filterBar) renderOverlay() string {
 	title := briefingV2TitleStyle.Render("Filter Sources")
 
 	// Help line
-	help := overlayHelpStyle.Render("↑↓←→ navigate  space toggle  a all  esc close")
+	helpText := "↑↓←→ navigate  space toggle  a all  esc close"
+	if len(f.failing) > 0 {
+		helpText += "  ! failing"
+	}
+	help := overlayHelpStyle.Render(helpText)
 
 	// Compose panel content
 	content := title + "\n\n" + grid + "\n\n" + help
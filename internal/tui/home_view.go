@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -15,7 +16,7 @@ var asciiLogo = []string{
 	`╚═════╝ ╚══════╝  ╚═══╝  ╚═╝  ╚═══╝╚══════╝ ╚══╝╚══╝ ╚══════╝`,
 }
 
-func renderHomeScreen(width, height int, hasBriefing bool, updateVersion string, statusMessage string) string {
+func renderHomeScreen(width, height int, hasBriefing bool, updateVersion string, statusMessage string, failing int, queued int) string {
 	logoStyle := lipgloss.NewStyle().Foreground(colorAccent)
 	keyStyle := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
 	labelStyle := lipgloss.NewStyle().Foreground(colorText)
@@ -34,7 +35,13 @@ func renderHomeScreen(width, height int, hasBriefing bool, updateVersion string,
 		lines = append(lines, "          "+keyStyle.Render("[b]")+"  "+labelStyle.Render("Today's Briefing"))
 	}
 	lines = append(lines, "          "+keyStyle.Render("[e]")+"  "+labelStyle.Render("Browse / Explore"))
-	lines = append(lines, "          "+keyStyle.Render("[s]")+"  "+labelStyle.Render("Request a Source"))
+	lines = append(lines, "          "+keyStyle.Render("[*]")+"  "+labelStyle.Render("Saved"))
+	readLater := "Read Later"
+	if queued > 0 {
+		readLater += fmt.Sprintf(" (%d)", queued)
+	}
+	lines = append(lines, "          "+keyStyle.Render("[l]")+"  "+labelStyle.Render(readLater))
+	lines = append(lines, "          "+keyStyle.Render("[s]")+"  "+labelStyle.Render("Manage Sources"))
 	lines = append(lines, "")
 	lines = append(lines, "          "+keyStyle.Render("[q]")+"  "+labelStyle.Render("Quit"))
 
@@ -48,6 +55,12 @@ func renderHomeScreen(width, height int, hasBriefing bool, updateVersion string,
 		lines = append(lines, "          "+statusStyle.Render(statusMessage))
 	}
 
+	// Failing sources
+	if failing > 0 {
+		lines = append(lines, "")
+		lines = append(lines, "          "+failingSourceStyle.Render(fmt.Sprintf("⚠ %d source(s) failing — run `devnews sources` for details", failing)))
+	}
+
 	// Update notification
 	if updateVersion != "" {
 		lines = append(lines, "")
@@ -46,8 +46,11 @@ func renderListItem(a cache.Article, selected bool, width int) string {
 	timeStr := relativeTime(a.Published)
 	timeStyle := timeColor(a.Published)
 
-	// Right side: optional AI marker + time
+	// Right side: optional star and AI markers + time
 	var rightParts []string
+	if a.Starred {
+		rightParts = append(rightParts, starredStyle.Render("★"))
+	}
 	if a.FullSummary != "" {
 		rightParts = append(rightParts, itemAIMarkerStyle.Render("AI"))
 	}
@@ -84,7 +87,7 @@ func renderListItem(a cache.Article, selected bool, width int) string {
 	// Line 2: category badge (colored)
 	line2 := "    "
 	if a.Category != "" {
-		line2 += categoryStyle(a.Category).Render(a.Category)
+		line2 += categoryBadges(a)
 	} else {
 		line2 += itemSourceStyle.Render(a.Source)
 	}
@@ -92,6 +95,18 @@ func renderListItem(a cache.Article, selected bool, width int) string {
 	return line1 + "\n" + line2
 }
 
+// categoryBadges renders the article's primary category, followed by its
+// secondary labels in a fainter color.
+func categoryBadges(a cache.Article) string {
+	badges := []string{categoryStyle(a.Category).Render(a.Category)}
+	for _, l := range a.Labels {
+		if l.Category != a.Category {
+			badges = append(badges, categoryStyle(l.Category).Faint(true).Render(l.Category))
+		}
+	}
+	return strings.Join(badges, itemSourceStyle.Render(" · "))
+}
+
 func truncateStr(s string, n int) string {
 	if n <= 0 {
 		return ""
@@ -1,8 +1,11 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
 )
 
 func TestTruncateStr(t *testing.T) {
@@ -61,3 +64,14 @@ func TestRelativeTimeOld(t *testing.T) {
 		t.Errorf("relativeTime(old date) = %q, want %q", got, "Jun 15")
 	}
 }
+
+func TestRenderListItemStarMarker(t *testing.T) {
+	a := cache.Article{Title: "Post", Source: "GitHub", Published: time.Now()}
+	if strings.Contains(renderListItem(a, false, 60), "★") {
+		t.Error("unstarred article should not show a star")
+	}
+	a.Starred = true
+	if !strings.Contains(renderListItem(a, false, 60), "★") {
+		t.Error("starred article should show a star")
+	}
+}
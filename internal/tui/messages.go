@@ -1,8 +1,11 @@
 package tui
 
 import (
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/matheuskafuri/devnews/internal/ai"
 	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/matheuskafuri/devnews/internal/feed"
 )
 
 type feedsLoadedMsg struct {
@@ -16,6 +19,15 @@ type feedErrMsg struct {
 type refreshDoneMsg struct {
 	count int
 	errs  []error
+	err   error // the refresh could not be saved, e.g. validators or health
+}
+
+type sourceHealthMsg struct {
+	failing []string
+}
+
+type queueLoadedMsg struct {
+	items []cache.QueueItem
 }
 
 type summaryLoadedMsg struct {
@@ -24,11 +36,30 @@ type summaryLoadedMsg struct {
 }
 
 type whyItMattersMsg struct {
-	cardIndex int
 	articleID string
 	text      string
 }
 
+// prefetchPlannedMsg carries the AI jobs to prefetch after a refresh.
+type prefetchPlannedMsg struct {
+	jobs []cache.AIJob
+}
+
+// prefetchJobDoneMsg reports a finished prefetch job, wrapping the message
+// that applies its result (nil when it failed).
+type prefetchJobDoneMsg struct {
+	result tea.Msg
+}
+
+// articlesClassifiedMsg reports that the AI assigned categories to new
+// articles, so the list should be reloaded.
+type articlesClassifiedMsg struct{}
+
+// aiJobsLoadedMsg carries AI jobs left unfinished by earlier runs.
+type aiJobsLoadedMsg struct {
+	jobs []cache.AIJob
+}
+
 type themesMsg struct {
 	themes []string
 }
@@ -41,6 +72,24 @@ type sourceRequestResultMsg struct {
 	err error
 }
 
+// sourceCheckedMsg carries the result of a trial fetch from the source form.
+type sourceCheckedMsg struct {
+	source     config.Source
+	articles   []cache.Article
+	candidates []feed.Candidate
+	err        error
+}
+
+// sourcesSavedMsg carries the sources reloaded after a config change. When
+// saved is set the config file was written, even if err reports a later step
+// that failed.
+type sourcesSavedMsg struct {
+	sources []config.Source
+	status  string
+	saved   bool
+	err     error
+}
+
 type clearStatusMsg struct{}
 
 type fullSummaryLoadedMsg struct {
@@ -26,13 +26,16 @@ func renderPreview(article *cache.Article, width, height, scroll int, loadingSum
 		fmt.Sprintf("%s · %s", article.Source, article.Published.Format("Jan 2, 2006")),
 	)
 
+	if article.Starred {
+		source += "  " + starredStyle.Render("★ Saved")
+	}
+
 	var parts []string
 	parts = append(parts, title, source)
 
 	// Category (colored)
 	if article.Category != "" {
-		cat := categoryStyle(article.Category).Render(article.Category)
-		parts = append(parts, cat)
+		parts = append(parts, categoryBadges(*article))
 	}
 
 	parts = append(parts, rule)
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+// queueLimit caps the items loaded into the Read Later view, like the
+// article list's default limit.
+const queueLimit = 500
+
+// loadQueueCmd runs an optional change against the reading queue and then
+// reloads it, so the view always reflects what was persisted.
+func (a *App) loadQueueCmd(change func(db *cache.Cache) error) tea.Cmd {
+	db := a.db
+	return func() tea.Msg {
+		if change != nil {
+			if err := change(db); err != nil {
+				return feedErrMsg{err: fmt.Errorf("reading queue: %w", err)}
+			}
+		}
+		items, err := db.Queue(true, queueLimit)
+		if err != nil {
+			return feedErrMsg{err: err}
+		}
+		return queueLoadedMsg{items: items}
+	}
+}
+
+// enqueueCmd adds an article to the reading queue from browse mode.
+func (a *App) enqueueCmd(article cache.Article) tea.Cmd {
+	a.statusMessage = "Added to Read Later: " + article.Title
+	id := article.ID
+	return tea.Batch(
+		a.loadQueueCmd(func(db *cache.Cache) error { return db.Enqueue(id) }),
+		tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} }),
+	)
+}
+
+func (a *App) queuedCount() int {
+	n := 0
+	for _, q := range a.queue {
+		if !q.Finished() {
+			n++
+		}
+	}
+	return n
+}
+
+func (a *App) selectedQueueItem() (cache.QueueItem, bool) {
+	if a.queueCursor < 0 || a.queueCursor >= len(a.queue) {
+		return cache.QueueItem{}, false
+	}
+	return a.queue[a.queueCursor], true
+}
+
+func (a *App) handleQueueKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "h":
+		a.mode = modeHome
+		return a, nil
+	case "q":
+		return a, tea.Quit
+	case "j", "down":
+		if a.queueCursor < len(a.queue)-1 {
+			a.queueCursor++
+		}
+		return a, nil
+	case "k", "up":
+		if a.queueCursor > 0 {
+			a.queueCursor--
+		}
+		return a, nil
+	case "J", "K":
+		item, ok := a.selectedQueueItem()
+		if !ok || item.Finished() {
+			return a, nil
+		}
+		delta := 1
+		if msg.String() == "K" {
+			delta = -1
+		}
+		next := a.queueCursor + delta
+		if next >= 0 && next < len(a.queue) && !a.queue[next].Finished() {
+			a.queueCursor = next
+		}
+		id := item.Article.ID
+		return a, a.loadQueueCmd(func(db *cache.Cache) error { return db.MoveQueued(id, delta) })
+	case "o", "enter":
+		item, ok := a.selectedQueueItem()
+		if !ok {
+			return a, nil
+		}
+		id := item.Article.ID
+		return a, tea.Batch(
+			openBrowserCmd(item.Article.Link),
+			a.loadQueueCmd(func(db *cache.Cache) error {
+				if err := db.MarkArticleRead(id); err != nil {
+					return err
+				}
+				return db.FinishQueued(id)
+			}),
+		)
+	case "x":
+		item, ok := a.selectedQueueItem()
+		if !ok {
+			return a, nil
+		}
+		id := item.Article.ID
+		return a, a.loadQueueCmd(func(db *cache.Cache) error { return db.Dequeue(id) })
+	}
+	return a, nil
+}
+
+func renderQueueView(items []cache.QueueItem, cursor, width, height int) string {
+	contentWidth := width - 8
+	if contentWidth < 30 {
+		contentWidth = 30
+	}
+
+	finished := 0
+	for _, q := range items {
+		if q.Finished() {
+			finished++
+		}
+	}
+
+	var lines []string
+	lines = append(lines, "", "  "+briefingV2TitleStyle.Render("Read Later"))
+
+	if len(items) == 0 {
+		lines = append(lines, "",
+			"  "+briefingV2MetaStyle.Render("Your reading queue is empty."),
+			"  "+briefingV2MetaStyle.Render("Press a on an article in Browse to add it."))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, "  "+renderQueueProgress(finished, len(items), contentWidth/2), "")
+
+	// Each item takes two lines; keep the cursor in view.
+	visible := (height - len(lines) - 2) / 2
+	if visible < 1 {
+		visible = 1
+	}
+	start := 0
+	if cursor >= visible {
+		start = cursor - visible + 1
+	}
+	end := start + visible
+	if end > len(items) {
+		end = len(items)
+	}
+
+	position := 0
+	for i := range items[:start] {
+		if !items[i].Finished() {
+			position++
+		}
+	}
+	for i := start; i < end; i++ {
+		q := items[i]
+		var marker, title string
+		if q.Finished() {
+			marker = itemReadStyle.Render("✓  ")
+			title = itemReadStyle.Render(truncateStr(q.Article.Title, contentWidth-6))
+		} else {
+			position++
+			marker = itemUnreadStyle.Render(fmt.Sprintf("%-3d", position))
+			title = itemTitleStyle.Render(truncateStr(q.Article.Title, contentWidth-6))
+		}
+		if i == cursor {
+			marker = itemSelectedStyle.Render("▸ ") + marker
+			if !q.Finished() {
+				title = itemSelectedStyle.Render(truncateStr(q.Article.Title, contentWidth-6))
+			}
+		} else {
+			marker = "  " + marker
+		}
+
+		meta := q.Article.Source + " · added " + relativeTime(q.AddedAt)
+		if q.Finished() {
+			meta += " · read " + relativeTime(q.FinishedAt)
+		}
+		lines = append(lines, "  "+marker+title)
+		lines = append(lines, "       "+itemSourceStyle.Render(meta))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderQueueProgress(done, total, barWidth int) string {
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	filled := 0
+	if total > 0 {
+		filled = done * barWidth / total
+	}
+	bar := lipgloss.NewStyle().Foreground(colorAccent).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(colorSubtle).Render(strings.Repeat("░", barWidth-filled))
+	return bar + "  " + briefingV2MetaStyle.Render(fmt.Sprintf("%d of %d read", done, total))
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuskafuri/devnews/internal/cache"
+)
+
+func TestRenderQueueProgress(t *testing.T) {
+	got := renderQueueProgress(1, 4, 20)
+	if !strings.Contains(got, "1 of 4 read") {
+		t.Errorf("expected progress label, got %q", got)
+	}
+	if strings.Count(got, "█") != 5 {
+		t.Errorf("expected a quarter of the bar filled, got %q", got)
+	}
+}
+
+func TestRenderQueueViewNumbersUnfinished(t *testing.T) {
+	now := time.Now()
+	items := []cache.QueueItem{
+		{Article: cache.Article{Title: "First", Source: "A"}, Position: 1, AddedAt: now},
+		{Article: cache.Article{Title: "Second", Source: "B"}, Position: 2, AddedAt: now},
+		{Article: cache.Article{Title: "Done", Source: "C"}, Position: 3, AddedAt: now, FinishedAt: now},
+	}
+	got := renderQueueView(items, 0, 80, 40)
+	for _, want := range []string{"1  ", "2  ", "✓", "First", "Second", "Done", "1 of 3 read"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in queue view:\n%s", want, got)
+		}
+	}
+}
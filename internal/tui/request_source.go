@@ -4,53 +4,12 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
-	"time"
 
-	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func (a *App) handleRequestSourceKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		a.mode = modeHome
-		a.sourceNameInput.Blur()
-		a.sourceURLInput.Blur()
-		return a, nil
-	case "tab", "shift+tab":
-		if a.sourceFormFocus == 0 {
-			a.sourceFormFocus = 1
-			a.sourceNameInput.Blur()
-			a.sourceURLInput.Focus()
-		} else {
-			a.sourceFormFocus = 0
-			a.sourceURLInput.Blur()
-			a.sourceNameInput.Focus()
-		}
-		return a, textinput.Blink
-	case "enter":
-		name := strings.TrimSpace(a.sourceNameInput.Value())
-		url := strings.TrimSpace(a.sourceURLInput.Value())
-		if name == "" || url == "" {
-			a.statusMessage = "Both name and URL are required"
-			return a, tea.Tick(3*time.Second, func(time.Time) tea.Msg {
-				return clearStatusMsg{}
-			})
-		}
-		a.sourceNameInput.Blur()
-		a.sourceURLInput.Blur()
-		return a, submitSourceRequest(name, url)
-	}
-
-	var cmd tea.Cmd
-	if a.sourceFormFocus == 0 {
-		a.sourceNameInput, cmd = a.sourceNameInput.Update(msg)
-	} else {
-		a.sourceURLInput, cmd = a.sourceURLInput.Update(msg)
-	}
-	return a, cmd
-}
-
+// submitSourceRequest files a GitHub issue asking for the source to be added
+// to the built-in defaults.
 func submitSourceRequest(name, url string) tea.Cmd {
 	return func() tea.Msg {
 		title := fmt.Sprintf("[Source Request] %s", name)
@@ -86,20 +45,3 @@ func submitSourceRequest(name, url string) tea.Cmd {
 		return sourceRequestResultMsg{}
 	}
 }
-
-func (a *App) renderRequestSourceOverlay() string {
-	var b strings.Builder
-	b.WriteString(overlayTitleStyle.Render("Request a Source"))
-	b.WriteString("\n\n")
-	b.WriteString(overlayLabelStyle.Render("Name"))
-	b.WriteString("\n")
-	b.WriteString(a.sourceNameInput.View())
-	b.WriteString("\n\n")
-	b.WriteString(overlayLabelStyle.Render("URL"))
-	b.WriteString("\n")
-	b.WriteString(a.sourceURLInput.View())
-	b.WriteString("\n\n")
-	b.WriteString(overlayHintStyle.Render("tab switch  enter submit  esc cancel"))
-
-	return overlayBoxStyle(50).Render(b.String())
-}
@@ -0,0 +1,501 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/matheuskafuri/devnews/internal/feed"
+)
+
+// sourceCheckTimeout bounds the trial fetch run before a source is saved.
+const sourceCheckTimeout = 20 * time.Second
+
+// sourcePreviewItems is how many parsed items the form shows after a check.
+const sourcePreviewItems = 5
+
+// sourcePreview is the outcome of the last trial fetch in the source form.
+// When the URL was a web page advertising several feeds, candidates holds
+// them and nothing was fetched yet.
+type sourcePreview struct {
+	source     config.Source
+	articles   []cache.Article
+	candidates []feed.Candidate
+	err        error
+}
+
+// openSourceManager shows the source list overlay from the home screen.
+func (a *App) openSourceManager() {
+	a.mode = modeSources
+	a.sourceForm = false
+	a.sourceConfirmDelete = false
+	a.builtinSources = make(map[string]bool, len(a.cfg.Sources))
+	for _, s := range a.cfg.Sources {
+		a.builtinSources[s.Name] = config.IsDefaultSource(s.Name)
+	}
+	if a.sourceCursor >= len(a.cfg.Sources) {
+		a.sourceCursor = max(0, len(a.cfg.Sources)-1)
+	}
+}
+
+// openSourceForm opens the add form, or the edit form when s is non-nil.
+func (a *App) openSourceForm(s *config.Source) tea.Cmd {
+	a.sourceForm = true
+	a.sourceEditing = ""
+	a.sourceType = "rss"
+	a.sourcePreview = nil
+	a.sourceNameInput.SetValue("")
+	a.sourceURLInput.SetValue("")
+	if s != nil {
+		a.sourceEditing = s.Name
+		a.sourceType = s.Type
+		a.sourceNameInput.SetValue(s.Name)
+		a.sourceURLInput.SetValue(s.URL)
+	}
+	a.sourceFormFocus = 0
+	a.sourceNameInput.Focus()
+	a.sourceURLInput.Blur()
+	return textinput.Blink
+}
+
+func (a *App) closeSourceForm() {
+	a.sourceForm = false
+	a.sourceChecking = false
+	a.sourcePreview = nil
+	a.sourceNameInput.Blur()
+	a.sourceURLInput.Blur()
+}
+
+func (a *App) selectedSource() (config.Source, bool) {
+	if a.sourceCursor < 0 || a.sourceCursor >= len(a.cfg.Sources) {
+		return config.Source{}, false
+	}
+	return a.cfg.Sources[a.sourceCursor], true
+}
+
+// formSource is the source described by the form inputs.
+func (a *App) formSource() config.Source {
+	return config.Source{
+		Name:    strings.TrimSpace(a.sourceNameInput.Value()),
+		URL:     strings.TrimSpace(a.sourceURLInput.Value()),
+		Type:    a.sourceType,
+		Enabled: true,
+	}
+}
+
+// setFormSource fills the form inputs from s, e.g. after discovery replaced a
+// page URL with its feed.
+func (a *App) setFormSource(s config.Source) {
+	a.sourceNameInput.SetValue(s.Name)
+	a.sourceURLInput.SetValue(s.URL)
+	a.sourceType = s.Type
+}
+
+// checkSourceCmd does a trial fetch of s with the fetcher for its type. For
+// rss and atom sources the URL may be any page of a site: it is resolved to a
+// feed first, and when the page offers several the candidates are returned
+// for the user to pick from. backfill is the global window applied to
+// sources without their own.
+func checkSourceCmd(s config.Source, adding bool, backfill string) tea.Cmd {
+	return func() tea.Msg {
+		fetcher, ok := feed.Lookup(s.Type)
+		if !ok {
+			return sourceCheckedMsg{source: s, err: fmt.Errorf("no fetcher registered for type %q", s.Type)}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sourceCheckTimeout)
+		defer cancel()
+
+		if s.Type == "rss" || s.Type == "atom" {
+			candidates, err := feed.Discover(ctx, s.URL)
+			if err != nil {
+				return sourceCheckedMsg{source: s, err: err}
+			}
+			if len(candidates) > 1 {
+				return sourceCheckedMsg{source: s, candidates: candidates}
+			}
+			s = withCandidate(s, candidates[0], adding)
+		}
+
+		// Preview what a refresh would store: like EnabledSources, fall back
+		// to the global window. The form's source is returned as entered.
+		fetched := s
+		if fetched.Backfill == "" {
+			fetched.Backfill = backfill
+		}
+		articles, err := fetcher.Fetch(ctx, fetched)
+		return sourceCheckedMsg{source: s, articles: articles, err: err}
+	}
+}
+
+// withCandidate points s at a discovered feed, taking the feed title as the
+// name when none was given. The type is only taken from the feed for new
+// sources; an edited source keeps its own.
+func withCandidate(s config.Source, c feed.Candidate, adding bool) config.Source {
+	s.URL = c.URL
+	if s.Name == "" {
+		s.Name = c.Title
+	}
+	if adding {
+		s.Type = c.Type
+	}
+	return s
+}
+
+// saveSourcesCmd applies change to the config file, then runs the optional
+// cache step, and reloads the sources so the TUI reflects exactly what was
+// written, even when the cache step fails.
+func (a *App) saveSourcesCmd(status string, change func(path string) error, cache func() error) tea.Cmd {
+	path := a.configPath
+	return func() tea.Msg {
+		if err := change(path); err != nil {
+			return sourcesSavedMsg{err: err}
+		}
+		var cacheErr error
+		if cache != nil {
+			cacheErr = cache()
+		}
+		cfg, err := config.Load(path)
+		if err != nil {
+			return sourcesSavedMsg{err: err}
+		}
+		return sourcesSavedMsg{sources: cfg.Sources, status: status, saved: true, err: cacheErr}
+	}
+}
+
+// addSourceCmd saves a checked source and stores the articles from its trial
+// fetch so they show up without waiting for the next refresh.
+func (a *App) addSourceCmd(p *sourcePreview) tea.Cmd {
+	db := a.db
+	s := p.source
+	articles := p.articles
+	return a.saveSourcesCmd("Added "+s.Name, func(path string) error {
+		_, err := config.AddSource(path, s)
+		return err
+	}, func() error {
+		return db.UpsertArticles(articles)
+	})
+}
+
+// updateSourceCmd saves an edited source, then carries its cached feed
+// state and articles over to the new name, dropping the state of the old
+// feed when the URL changed.
+func (a *App) updateSourceCmd(name string, s config.Source) tea.Cmd {
+	db := a.db
+	var oldURL string
+	for _, src := range a.cfg.Sources {
+		if src.Name == name {
+			oldURL = src.URL
+		}
+	}
+	return a.saveSourcesCmd("Updated "+s.Name, func(path string) error {
+		return config.UpdateSource(path, name, s)
+	}, func() error {
+		return db.EditSource(name, strings.TrimSpace(s.Name), strings.TrimSpace(s.URL) != oldURL)
+	})
+}
+
+func (a *App) handleSourcesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.sourceForm {
+		return a.handleSourceFormKey(msg)
+	}
+
+	key := msg.String()
+	if key != "x" {
+		a.sourceConfirmDelete = false
+	}
+	switch key {
+	case "esc":
+		a.mode = modeHome
+		return a, nil
+	case "j", "down":
+		if a.sourceCursor < len(a.cfg.Sources)-1 {
+			a.sourceCursor++
+		}
+		return a, nil
+	case "k", "up":
+		if a.sourceCursor > 0 {
+			a.sourceCursor--
+		}
+		return a, nil
+	case "a":
+		return a, a.openSourceForm(nil)
+	case "e", "enter":
+		s, ok := a.selectedSource()
+		if !ok {
+			return a, nil
+		}
+		if a.builtinSources[s.Name] {
+			return a, a.setStatus(config.ErrBuiltinSource.Error())
+		}
+		return a, a.openSourceForm(&s)
+	case " ":
+		s, ok := a.selectedSource()
+		if !ok {
+			return a, nil
+		}
+		status := "Enabled " + s.Name
+		if s.Enabled {
+			status = "Disabled " + s.Name
+		}
+		return a, a.saveSourcesCmd(status, func(path string) error {
+			return config.SetSourceEnabled(path, s.Name, !s.Enabled)
+		}, nil)
+	case "x":
+		s, ok := a.selectedSource()
+		if !ok {
+			return a, nil
+		}
+		if a.builtinSources[s.Name] {
+			return a, a.setStatus(config.ErrBuiltinSource.Error())
+		}
+		if !a.sourceConfirmDelete {
+			a.sourceConfirmDelete = true
+			return a, a.setStatus("Press x again to delete " + s.Name)
+		}
+		a.sourceConfirmDelete = false
+		return a, a.saveSourcesCmd("Deleted "+s.Name, func(path string) error {
+			return config.RemoveSource(path, s.Name)
+		}, nil)
+	}
+	return a, nil
+}
+
+func (a *App) handleSourceFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.closeSourceForm()
+		return a, nil
+	case "tab", "shift+tab":
+		if a.sourceFormFocus == 0 {
+			a.sourceFormFocus = 1
+			a.sourceNameInput.Blur()
+			a.sourceURLInput.Focus()
+		} else {
+			a.sourceFormFocus = 0
+			a.sourceURLInput.Blur()
+			a.sourceNameInput.Focus()
+		}
+		return a, textinput.Blink
+	case "ctrl+g":
+		if a.sourceEditing != "" {
+			return a, nil
+		}
+		s := a.formSource()
+		if s.Name == "" || s.URL == "" {
+			return a, a.setStatus("Both name and URL are required")
+		}
+		a.closeSourceForm()
+		return a, submitSourceRequest(s.Name, s.URL)
+	case "up", "down":
+		p := a.sourcePreview
+		if p == nil || len(p.candidates) == 0 {
+			return a, nil
+		}
+		if msg.String() == "up" && a.sourceCandidate > 0 {
+			a.sourceCandidate--
+		} else if msg.String() == "down" && a.sourceCandidate < len(p.candidates)-1 {
+			a.sourceCandidate++
+		}
+		return a, nil
+	case "enter":
+		if a.sourceChecking {
+			return a, nil
+		}
+		s := a.formSource()
+		if s.URL == "" {
+			return a, a.setStatus("A URL is required")
+		}
+		adding := a.sourceEditing == ""
+		p := a.sourcePreview
+		// Picking a discovered feed checks it in place of the page.
+		if p != nil && len(p.candidates) > 0 && p.source == s {
+			s = withCandidate(s, p.candidates[a.sourceCandidate], adding)
+			a.setFormSource(s)
+			a.sourceChecking = true
+			a.sourcePreview = nil
+			return a, tea.Batch(checkSourceCmd(s, adding, a.cfg.Backfill), a.spinner.Tick)
+		}
+		// A successful check of the same inputs confirms the save.
+		if p != nil && p.err == nil && len(p.candidates) == 0 && p.source == s {
+			if adding {
+				return a, a.addSourceCmd(p)
+			}
+			return a, a.updateSourceCmd(a.sourceEditing, s)
+		}
+		a.sourceChecking = true
+		a.sourcePreview = nil
+		return a, tea.Batch(checkSourceCmd(s, adding, a.cfg.Backfill), a.spinner.Tick)
+	}
+
+	var cmd tea.Cmd
+	if a.sourceFormFocus == 0 {
+		a.sourceNameInput, cmd = a.sourceNameInput.Update(msg)
+	} else {
+		a.sourceURLInput, cmd = a.sourceURLInput.Update(msg)
+	}
+	return a, cmd
+}
+
+// setStatus shows a transient status message.
+func (a *App) setStatus(msg string) tea.Cmd {
+	a.statusMessage = msg
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
+}
+
+func (a *App) renderSourcesOverlay() string {
+	if a.sourceForm {
+		return a.renderSourceForm()
+	}
+
+	var b strings.Builder
+	b.WriteString(overlayTitleStyle.Render("Sources"))
+	b.WriteString("\n\n")
+
+	sources := a.cfg.Sources
+	if len(sources) == 0 {
+		b.WriteString(overlayHintStyle.Render("No sources configured. Press a to add one."))
+	}
+
+	visible := a.height - 16
+	if visible < 3 {
+		visible = 3
+	}
+	start := 0
+	if a.sourceCursor >= visible {
+		start = a.sourceCursor - visible + 1
+	}
+	end := min(start+visible, len(sources))
+
+	for i := start; i < end; i++ {
+		s := sources[i]
+		check := "[x] "
+		nameStyle := itemTitleStyle
+		if !s.Enabled {
+			check = "[ ] "
+			nameStyle = itemReadStyle
+		}
+		cursor := "  "
+		if i == a.sourceCursor {
+			cursor = itemSelectedStyle.Render("▸ ")
+			if s.Enabled {
+				nameStyle = itemSelectedStyle
+			}
+		}
+		line := cursor + check + nameStyle.Render(truncateStr(s.Name, 36))
+		if a.builtinSources[s.Name] {
+			line += "  " + overlayHintStyle.Render("built-in")
+		}
+		b.WriteString(line + "\n")
+	}
+	if end < len(sources) {
+		b.WriteString(overlayHintStyle.Render(fmt.Sprintf("  … %d more", len(sources)-end)) + "\n")
+	}
+
+	if a.statusMessage != "" {
+		b.WriteString("\n" + renderOverlayStatus(a.statusMessage))
+	}
+	b.WriteString("\n")
+	b.WriteString(overlayHintStyle.Render("a add  e edit  space toggle  x delete  esc close"))
+
+	return overlayBoxStyle(60).Render(b.String())
+}
+
+func (a *App) renderSourceForm() string {
+	var b strings.Builder
+	title := "Add Source"
+	if a.sourceEditing != "" {
+		title = "Edit " + a.sourceEditing
+	}
+	b.WriteString(overlayTitleStyle.Render(title))
+	b.WriteString("\n\n")
+	b.WriteString(overlayLabelStyle.Render("Name"))
+	b.WriteString("\n")
+	b.WriteString(a.sourceNameInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(overlayLabelStyle.Render("URL"))
+	b.WriteString("\n")
+	b.WriteString(a.sourceURLInput.View())
+	b.WriteString("\n\n")
+
+	confirm := false
+	switch p := a.sourcePreview; {
+	case a.sourceChecking:
+		b.WriteString(a.spinner.View() + " " + overlayHintStyle.Render("Checking feed…") + "\n\n")
+	case p != nil && p.err != nil:
+		b.WriteString(failingSourceStyle.Render("✗ "+truncateStr(p.err.Error(), 100)) + "\n\n")
+	case p != nil && len(p.candidates) > 0:
+		b.WriteString(renderSourceCandidates(p.candidates, a.sourceCandidate, 50))
+		b.WriteString("\n")
+	case p != nil:
+		confirm = p.source == a.formSource()
+		b.WriteString(renderSourcePreview(p.articles, 50))
+		b.WriteString("\n")
+	}
+
+	if a.statusMessage != "" {
+		b.WriteString(renderOverlayStatus(a.statusMessage) + "\n")
+	}
+
+	hints := "tab switch  enter check  esc back"
+	if p := a.sourcePreview; p != nil && len(p.candidates) > 0 {
+		hints = "↑/↓ choose  enter check feed  esc back"
+	} else if confirm {
+		hints = "tab switch  enter save  esc back"
+	}
+	if a.sourceEditing == "" {
+		hints += "  ctrl+g request upstream"
+	}
+	b.WriteString(overlayHintStyle.Render(hints))
+
+	return overlayBoxStyle(60).Render(b.String())
+}
+
+// renderSourcePreview lists the first items parsed by a trial fetch.
+func renderSourcePreview(articles []cache.Article, width int) string {
+	ok := lipgloss.NewStyle().Foreground(colorAccent)
+	if len(articles) == 0 {
+		return ok.Render("✓ Feed OK") + " " + overlayHintStyle.Render("(no items inside the backfill window)") + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(ok.Render(fmt.Sprintf("✓ Feed OK — %d item(s)", len(articles))) + "\n")
+	for i, art := range articles {
+		if i == sourcePreviewItems {
+			break
+		}
+		b.WriteString("  " + itemTitleStyle.Render(truncateStr(art.Title, width)) + "\n")
+	}
+	return b.String()
+}
+
+// renderSourceCandidates lists the feeds discovered on a page.
+func renderSourceCandidates(candidates []feed.Candidate, cursor, width int) string {
+	var b strings.Builder
+	b.WriteString(overlayLabelStyle.Render(fmt.Sprintf("Found %d feeds", len(candidates))) + "\n")
+	for i, c := range candidates {
+		label := c.URL
+		if c.Title != "" {
+			label = c.Title + "  " + overlayHintStyle.Render(c.URL)
+		}
+		if i == cursor {
+			b.WriteString(itemSelectedStyle.Render("▸ ") + truncateStr(label, width) + "\n")
+		} else {
+			b.WriteString("  " + truncateStr(label, width) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func renderOverlayStatus(msg string) string {
+	if strings.HasPrefix(msg, "Error:") {
+		return failingSourceStyle.Render(msg)
+	}
+	return overlayHintStyle.Render(msg)
+}
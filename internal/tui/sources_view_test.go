@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matheuskafuri/devnews/internal/cache"
+	"github.com/matheuskafuri/devnews/internal/config"
+	"github.com/matheuskafuri/devnews/internal/feed"
+)
+
+func TestFilterBarSetSourcesDropsRemoved(t *testing.T) {
+	f := newFilterBar([]string{"A", "B", "C"})
+	f.toggle("A")
+	f.toggle("C")
+	f.gridCursor = 3
+
+	f.setSources([]string{"A", "B"})
+
+	if got := f.activeSources(); len(got) != 1 || got[0] != "A" {
+		t.Errorf("expected only A to stay selected, got %v", got)
+	}
+	if f.gridCursor != 2 {
+		t.Errorf("expected grid cursor clamped to 2, got %d", f.gridCursor)
+	}
+}
+
+func TestSourceManagerKeys(t *testing.T) {
+	cfg := &config.Config{Sources: []config.Source{
+		{Name: "Mine", Type: "atom", URL: "https://example.com/feed", Enabled: true},
+	}}
+	app := NewApp(RunOpts{Cfg: cfg})
+	app.openSourceManager()
+	app.builtinSources["Mine"] = true
+
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if app.statusMessage != config.ErrBuiltinSource.Error() {
+		t.Errorf("expected built-in sources to refuse delete, got %q", app.statusMessage)
+	}
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if app.sourceForm {
+		t.Error("expected built-in sources to refuse edit")
+	}
+
+	app.builtinSources["Mine"] = false
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if !app.sourceForm || app.sourceEditing != "Mine" || app.sourceURLInput.Value() != "https://example.com/feed" {
+		t.Fatalf("expected edit form prefilled for Mine, got editing=%q url=%q", app.sourceEditing, app.sourceURLInput.Value())
+	}
+	if got := app.formSource(); got.Type != "atom" {
+		t.Errorf("expected edit to keep the source type, got %q", got.Type)
+	}
+
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyEsc})
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.sourceChecking || app.statusMessage != "A URL is required" {
+		t.Errorf("expected empty form to be rejected, got checking=%v status=%q", app.sourceChecking, app.statusMessage)
+	}
+}
+
+func TestRenderSourcePreview(t *testing.T) {
+	got := renderSourcePreview([]cache.Article{{Title: "One"}, {Title: "Two"}}, 40)
+	for _, want := range []string{"2 item(s)", "One", "Two"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in preview:\n%s", want, got)
+		}
+	}
+	if got := renderSourcePreview(nil, 40); !strings.Contains(got, "no items") {
+		t.Errorf("expected empty feed note, got %q", got)
+	}
+}
+
+func TestSourceFormPicksDiscoveredFeed(t *testing.T) {
+	app := NewApp(RunOpts{Cfg: &config.Config{}})
+	app.openSourceManager()
+	app.openSourceForm(nil)
+	app.sourceURLInput.SetValue("https://example.com")
+	page := app.formSource()
+	app.sourcePreview = &sourcePreview{source: page, candidates: []feed.Candidate{
+		{URL: "https://example.com/posts.rss", Title: "Posts", Type: "rss"},
+		{URL: "https://example.com/atom.xml", Title: "Everything", Type: "atom"},
+	}}
+
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyDown})
+	app.handleSourcesKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	got := app.formSource()
+	if got.URL != "https://example.com/atom.xml" || got.Name != "Everything" || got.Type != "atom" {
+		t.Errorf("expected the second candidate in the form, got %+v", got)
+	}
+	if !app.sourceChecking {
+		t.Error("expected the picked feed to be checked")
+	}
+}
+
+func TestWithCandidateKeepsEditedType(t *testing.T) {
+	c := feed.Candidate{URL: "https://example.com/atom.xml", Title: "Feed", Type: "atom"}
+	got := withCandidate(config.Source{Name: "Mine", Type: "rss"}, c, false)
+	if got.Name != "Mine" || got.Type != "rss" || got.URL != c.URL {
+		t.Errorf("expected name and type kept when editing, got %+v", got)
+	}
+}
+
+func TestCheckSourceUsesGlobalBackfill(t *testing.T) {
+	pub := time.Now().Add(-10 * 24 * time.Hour).Format(time.RFC1123Z)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprintf(w, `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Old</title>
+<item><title>Old post</title><link>https://example.com/old</link><pubDate>%s</pubDate></item>
+</channel></rss>`, pub)
+	}))
+	defer srv.Close()
+	s := config.Source{Name: "Old", Type: "rss", URL: srv.URL}
+
+	msg := checkSourceCmd(s, false, "")()
+	if m := msg.(sourceCheckedMsg); m.err != nil || len(m.articles) != 0 {
+		t.Errorf("expected the 10-day-old item outside the default window, got %d (err %v)", len(m.articles), m.err)
+	}
+	msg = checkSourceCmd(s, false, "30d")()
+	m := msg.(sourceCheckedMsg)
+	if m.err != nil || len(m.articles) != 1 {
+		t.Errorf("expected the item within the global backfill, got %d (err %v)", len(m.articles), m.err)
+	}
+	if m.source.Backfill != "" {
+		t.Errorf("expected the form's source unchanged, got backfill %q", m.source.Backfill)
+	}
+}
+
+func TestFailedCacheWriteStillShowsSavedSources(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte("sources: []\n"), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	db, err := cache.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	db.Close() // the cache write fails
+
+	app := NewApp(RunOpts{Cfg: &config.Config{}, DB: db, ConfigPath: cfgPath})
+	app.openSourceManager()
+	s := config.Source{Name: "Lobsters", Type: "rss", URL: "https://lobste.rs/rss"}
+	msg := app.addSourceCmd(&sourcePreview{source: s, articles: []cache.Article{{ID: "l1", Source: "Lobsters", Title: "L", Link: "https://lobste.rs/1"}}})()
+	app.Update(msg)
+
+	found := false
+	for _, src := range app.cfg.Sources {
+		found = found || src.Name == "Lobsters"
+	}
+	if !found {
+		t.Error("expected the source written to the config to be listed")
+	}
+	if !strings.HasPrefix(app.statusMessage, "Error:") {
+		t.Errorf("expected the cache error reported, got %q", app.statusMessage)
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-func renderStatusBar(articleCount int, filterLabel string, streak int, width int, searching bool, refreshing bool, lay layout) string {
+func renderStatusBar(articleCount int, filterLabel string, streak int, width int, searching bool, refreshing bool, lay layout, failing int, sortByDate bool, prefetch string) string {
 	streakAccentStyle := lipgloss.NewStyle().
 		Foreground(colorAccent).
 		Bold(true)
@@ -27,15 +27,25 @@ func renderStatusBar(articleCount int, filterLabel string, streak int, width int
 	if streak >= 1 {
 		left += fmt.Sprintf(" · %s %dd", streakAccentStyle.Render("streak"), streak)
 	}
+	if failing > 0 {
+		left += " · " + failingSourceStyle.Render(fmt.Sprintf("⚠ %d failing", failing))
+	}
 
-	right := " S summary  T theme  h home  / search  f filter  q quit "
+	right := " s star  a later  S summary  T theme  h home  / search  f filter  q quit "
 
 	if searching {
-		right = " esc cancel  enter search "
+		sortLabel := "relevance"
+		if sortByDate {
+			sortLabel = "date"
+		}
+		right = " esc cancel  enter search  tab sort: " + sortLabel + " "
 	}
 	if refreshing {
 		left += " (refreshing...)"
 	}
+	if prefetch != "" {
+		left += " · " + prefetch
+	}
 
 	gap := width - lipgloss.Width(left) - lipgloss.Width(right)
 	if gap < 0 {
@@ -11,6 +11,8 @@ var (
 	colorSubtle  lipgloss.TerminalColor = lipgloss.Color("#222222")
 	colorSurface lipgloss.TerminalColor = lipgloss.Color("#111111")
 	colorBody    lipgloss.TerminalColor = lipgloss.Color("#AAAAAA")
+	colorError   lipgloss.TerminalColor = lipgloss.Color("#FF5555")
+	colorStar    lipgloss.TerminalColor = lipgloss.Color("#F1C40F")
 
 	headerStyle = lipgloss.NewStyle().
 			Bold(true).
@@ -150,6 +152,12 @@ var (
 
 	// Per-render cached styles (rebuilt by applyTheme)
 	itemTimeFreshStyle = lipgloss.NewStyle().Foreground(colorAccent)
+
+	// Warning for sources that keep failing to fetch
+	failingSourceStyle = lipgloss.NewStyle().Foreground(colorError).Bold(true)
+
+	// Star marker for saved articles
+	starredStyle = lipgloss.NewStyle().Foreground(colorStar).Bold(true)
 )
 
 func overlayBoxStyle(width int) lipgloss.Style {
@@ -1,6 +1,10 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/matheuskafuri/devnews/internal/classify"
+	"github.com/matheuskafuri/devnews/internal/config"
+)
 
 // Theme holds all color slots for a devnews theme.
 type Theme struct {
@@ -12,6 +16,8 @@ type Theme struct {
 	Subtle  lipgloss.Color
 	Surface lipgloss.Color
 	Body    lipgloss.Color
+	Error   lipgloss.Color // failing sources and failed checks
+	Star    lipgloss.Color // saved article markers
 
 	// Briefing-specific
 	BriefingTitle lipgloss.Color
@@ -34,6 +40,8 @@ var themes = map[string]Theme{
 		Subtle:  lipgloss.Color("#222222"),
 		Surface: lipgloss.Color("#111111"),
 		Body:    lipgloss.Color("#AAAAAA"),
+		Error:   lipgloss.Color("#FF5555"),
+		Star:    lipgloss.Color("#F1C40F"),
 		BriefingTitle: lipgloss.Color("#00FFFF"),
 		BriefingBody:  lipgloss.Color("#E0E0E0"),
 		BriefingMeta:  lipgloss.Color("#00E5FF"),
@@ -58,6 +66,8 @@ var themes = map[string]Theme{
 		Subtle:  lipgloss.Color("#282A36"),
 		Surface: lipgloss.Color("#21222C"),
 		Body:    lipgloss.Color("#BFBFBF"),
+		Error:   lipgloss.Color("#FF5555"),
+		Star:    lipgloss.Color("#F1FA8C"),
 		BriefingTitle: lipgloss.Color("#FF79C6"),
 		BriefingBody:  lipgloss.Color("#F8F8F2"),
 		BriefingMeta:  lipgloss.Color("#8BE9FD"),
@@ -82,6 +92,8 @@ var themes = map[string]Theme{
 		Subtle:  lipgloss.Color("#3B4252"),
 		Surface: lipgloss.Color("#2E3440"),
 		Body:    lipgloss.Color("#D8DEE9"),
+		Error:   lipgloss.Color("#BF616A"),
+		Star:    lipgloss.Color("#EBCB8B"),
 		BriefingTitle: lipgloss.Color("#88C0D0"),
 		BriefingBody:  lipgloss.Color("#D8DEE9"),
 		BriefingMeta:  lipgloss.Color("#81A1C1"),
@@ -106,6 +118,8 @@ var themes = map[string]Theme{
 		Subtle:  lipgloss.Color("#EEE8D5"),
 		Surface: lipgloss.Color("#FDF6E3"),
 		Body:    lipgloss.Color("#657B83"),
+		Error:   lipgloss.Color("#DC322F"),
+		Star:    lipgloss.Color("#B58900"),
 		BriefingTitle: lipgloss.Color("#268BD2"),
 		BriefingBody:  lipgloss.Color("#073642"),
 		BriefingMeta:  lipgloss.Color("#2AA198"),
@@ -139,8 +153,38 @@ func GetTheme(name string) Theme {
 // currentTheme is the active theme. Set at startup.
 var currentTheme = themes["neon"]
 
+// customCategoryColors are the category colors set in the config. They win
+// over the colors of every theme.
+var customCategoryColors map[string]lipgloss.Color
+
+// setCategoryColors records the colors of the configured categories, keyed
+// by canonical category name.
+func setCategoryColors(categories []config.CategoryConfig) {
+	customCategoryColors = make(map[string]lipgloss.Color)
+	for _, c := range categories {
+		if c.Color == "" {
+			continue
+		}
+		name := c.Name
+		if cat, err := classify.ResolveAlias(name); err == nil {
+			name = string(cat)
+		}
+		customCategoryColors[name] = lipgloss.Color(c.Color)
+	}
+}
+
 // applyTheme updates all style variables to use the given theme's colors.
 func applyTheme(t Theme) {
+	if len(customCategoryColors) > 0 {
+		colors := make(map[string]lipgloss.Color, len(t.CategoryColors)+len(customCategoryColors))
+		for cat, c := range t.CategoryColors {
+			colors[cat] = c
+		}
+		for cat, c := range customCategoryColors {
+			colors[cat] = c
+		}
+		t.CategoryColors = colors
+	}
 	currentTheme = t
 
 	colorAccent = t.Accent
@@ -150,6 +194,8 @@ func applyTheme(t Theme) {
 	colorSubtle = t.Subtle
 	colorSurface = t.Surface
 	colorBody = t.Body
+	colorError = t.Error
+	colorStar = t.Star
 
 	// Rebuild all styles with new colors
 	headerStyle = lipgloss.NewStyle().Bold(true).Foreground(colorAccent).PaddingLeft(1)
@@ -192,6 +238,8 @@ func applyTheme(t Theme) {
 
 	// Per-render cached styles
 	itemTimeFreshStyle = lipgloss.NewStyle().Foreground(colorAccent)
+	failingSourceStyle = lipgloss.NewStyle().Foreground(colorError).Bold(true)
+	starredStyle = lipgloss.NewStyle().Foreground(colorStar).Bold(true)
 
 	// Filter overlay styles
 	overlayActiveNameStyle = lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
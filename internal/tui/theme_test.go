@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/matheuskafuri/devnews/internal/config"
+)
+
+func TestCustomCategoryColors(t *testing.T) {
+	t.Cleanup(func() {
+		setCategoryColors(nil)
+		applyTheme(GetTheme("neon"))
+	})
+
+	setCategoryColors([]config.CategoryConfig{
+		{Name: "Observability", Color: "#FFA500"},
+		{Name: "db", Color: "#123456"},
+		{Name: "Frontend"},
+	})
+	applyTheme(GetTheme("nord"))
+
+	for cat, want := range map[string]lipgloss.Color{
+		"Observability": "#FFA500",
+		"Databases":     "#123456",
+		"Security":      themes["nord"].CategoryColors["Security"],
+		"Frontend":      themes["nord"].CategoryDefault,
+	} {
+		if got := categoryStyle(cat).GetForeground(); got != want {
+			t.Errorf("categoryStyle(%q) color = %v, want %v", cat, got, want)
+		}
+	}
+	if themes["nord"].CategoryColors["Databases"] == "#123456" {
+		t.Error("custom colors must not modify the built-in theme")
+	}
+}
+
+func TestThemeStatusColors(t *testing.T) {
+	t.Cleanup(func() { applyTheme(GetTheme("neon")) })
+	for _, name := range ThemeNames() {
+		theme := GetTheme(name)
+		applyTheme(theme)
+		if got := failingSourceStyle.GetForeground(); got != theme.Error {
+			t.Errorf("%s: failing source color = %v, want %v", name, got, theme.Error)
+		}
+		if got := starredStyle.GetForeground(); got != theme.Star {
+			t.Errorf("%s: starred color = %v, want %v", name, got, theme.Star)
+		}
+	}
+}